@@ -1,13 +1,37 @@
-import { Component, Info, OpenApiV3, Path } from "fluid-oas";
+import {
+  Component,
+  Info,
+  OpenApiV3,
+  Path,
+  SecurityScheme,
+} from "fluid-oas";
 import { REGISTER_ENDPOINT } from "./paths/register";
 import { API_DOCS_ENDPOINT, SPEC_ENDPOINT } from "./paths/docs";
 import { HEALTHCHECK_ENDPOINT } from "./paths/healthcheck";
-import { MEMBER_ENDPOINT } from "./paths/member";
+import {
+  MEMBER_ATTEMPTS_ENDPOINT,
+  MEMBER_CODE_ENDPOINT,
+  MEMBER_STATUS_ENDPOINT,
+  MEMBER_VERIFY_ENDPOINT,
+} from "./paths/member";
+import { CHALLENGES_ENDPOINT } from "./paths/challenges";
+import { COHORTS_ENDPOINT } from "./paths/cohorts";
+import { LEADERBOARD_ENDPOINT } from "./paths/leaderboard";
+import {
+  ADMIN_MEMBERS_ENDPOINT,
+  ADMIN_MEMBER_ATTEMPTS_ENDPOINT,
+  ADMIN_MEMBER_FRONTEND_USAGE_ENDPOINT,
+  ADMIN_MEMBER_HISTORY_ENDPOINT,
+  ADMIN_MEMBER_SCORES_ENDPOINT,
+} from "./paths/admin";
 import {
   ALIEN_CHALLENGE_ENDPOINT,
   ALIEN_FRONTEND_CHALLENGE_ENDPOINT,
   SUBMIT_ENDPOINT,
   NGROK_ENDPOINT,
+  NGROK_JOB_ENDPOINT,
+  REPLAY_ENDPOINT,
+  SOLUTION_ENDPOINT,
 } from "./paths/challenge";
 import { BASE_ALIEN_SCHEMA, DETAILED_ALIEN_SCHEMA } from "./schema/index.ts";
 import { ALIEN_INVASION } from "./schema/alien.ts";
@@ -24,12 +48,29 @@ let oas = OpenApiV3.addOpenApiVersion("3.1.0")
       "/healthcheck": HEALTHCHECK_ENDPOINT,
       "/challenge": SPEC_ENDPOINT,
       "/api/v1/member/register": REGISTER_ENDPOINT,
-      "/api/v1/member": MEMBER_ENDPOINT,
+      "/api/v1/member/code": MEMBER_CODE_ENDPOINT,
+      "/api/v1/member/verify": MEMBER_VERIFY_ENDPOINT,
+      "/api/v1/member/{id}/attempts": MEMBER_ATTEMPTS_ENDPOINT,
+      "/api/v1/member/{id}/status": MEMBER_STATUS_ENDPOINT,
+      "/api/v1/challenges": CHALLENGES_ENDPOINT,
+      "/api/v1/cohorts": COHORTS_ENDPOINT,
+      "/api/v1/leaderboard": LEADERBOARD_ENDPOINT,
       "/api/v1/challenge/backend/{id}/aliens": ALIEN_CHALLENGE_ENDPOINT,
       "/api/v1/challenge/backend/{id}/aliens/submit": SUBMIT_ENDPOINT,
+      "/api/v1/challenge/backend/{id}/aliens/solution": SOLUTION_ENDPOINT,
+      "/api/v1/challenge/backend/{id}/aliens/{challengeId}/replay":
+        REPLAY_ENDPOINT,
       "/api/v1/challenge/frontend/{id}/aliens":
         ALIEN_FRONTEND_CHALLENGE_ENDPOINT,
       "/api/v1/challenge/backend/{id}/ngrok/submit": NGROK_ENDPOINT,
+      "/api/v1/challenge/backend/{id}/ngrok/jobs/{jobId}": NGROK_JOB_ENDPOINT,
+      "/api/v1/admin/members": ADMIN_MEMBERS_ENDPOINT,
+      "/api/v1/admin/members/{id}/scores": ADMIN_MEMBER_SCORES_ENDPOINT,
+      "/api/v1/admin/members/{id}/history": ADMIN_MEMBER_HISTORY_ENDPOINT,
+      "/api/v1/admin/members/{id}/frontend-usage":
+        ADMIN_MEMBER_FRONTEND_USAGE_ENDPOINT,
+      "/api/v1/admin/members/{id}/attempts/{challenge}":
+        ADMIN_MEMBER_ATTEMPTS_ENDPOINT,
     }),
   );
 
@@ -37,6 +78,12 @@ export const COMPONENT = Component.addSchemas({
   BaseAlien: BASE_ALIEN_SCHEMA,
   DetailedAlien: DETAILED_ALIEN_SCHEMA,
   AlienInvasion: ALIEN_INVASION,
+}).addSecuritySchemes({
+  staffToken: SecurityScheme.addType("http")
+    .addScheme("bearer")
+    .addDescription(
+      "Token given to interviewers and admins, scoped to the role it was issued for.",
+    ),
 });
 
 export const COMPONENT_MAPPINGS = COMPONENT.createMappings();
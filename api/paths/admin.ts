@@ -0,0 +1,215 @@
+import {
+  Array,
+  Boolean,
+  Integer,
+  MediaType,
+  Object,
+  Operation,
+  Parameter,
+  PathItem,
+  Response,
+  Responses,
+  String,
+} from "fluid-oas";
+import { ERROR, UUID } from "../schema";
+
+// Interviewers can read every candidate, admins can also change them.
+const INTERVIEWER = [{ staffToken: ["interviewer"] }];
+const ADMIN = [{ staffToken: ["admin"] }];
+
+const MEMBER_ID_PARAMETER = Parameter.schema
+  .addIn("path")
+  .addRequired(true)
+  .addName("id")
+  .addSchema(UUID);
+
+const ERROR_RESPONSES = {
+  "401": Response.addDescription(
+    "Missing or invalid staff token.",
+  ).addContents({
+    "application/json": MediaType.addSchema(ERROR),
+  }),
+  "403": Response.addDescription(
+    "Your role is not allowed to do this.",
+  ).addContents({
+    "application/json": MediaType.addSchema(ERROR),
+  }),
+  "500": Response.addDescription("Internal server error.").addContents({
+    "application/json": MediaType.addSchema(ERROR),
+  }),
+};
+
+const NOT_FOUND_RESPONSE = Response.addDescription(
+  "Candidate not found.",
+).addContents({
+  "application/json": MediaType.addSchema(ERROR),
+});
+
+const CANDIDATE = Object.addProperties({
+  id: UUID,
+  email: String,
+  nuid: String,
+  cohort: String.addDescription("Name of the cohort the candidate registered into."),
+  challengeVersion: Integer.addDescription(
+    "Version of the challenge generators the candidate was registered with.",
+  ),
+  registeredAt: String.addFormat("date-time"),
+}).addRequired([
+  "id",
+  "email",
+  "nuid",
+  "cohort",
+  "challengeVersion",
+  "registeredAt",
+]);
+
+export const ADMIN_MEMBERS_ENDPOINT = PathItem.addSummary(
+  "Look up candidates by Northeastern email address and/or NUID.",
+).addMethod({
+  get: Operation.addSecurity(INTERVIEWER)
+    .addParameters([
+      Parameter.schema
+        .addIn("query")
+        .addName("email")
+        .addDescription("Northeastern email address of the candidate.")
+        .addSchema(String),
+      Parameter.schema
+        .addIn("query")
+        .addName("nuid")
+        .addDescription("NUID of the candidate.")
+        .addSchema(String),
+    ])
+    .addResponses(
+      Responses({
+        "200": Response.addDescription(
+          "Every registration matching the email and NUID, newest first.",
+        ).addContents({
+          "application/json": MediaType.addSchema(Array.addItems(CANDIDATE)),
+        }),
+        "400": Response.addDescription(
+          "Neither an email address nor an NUID was given.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+        ...ERROR_RESPONSES,
+      }),
+    ),
+});
+
+const BEST_SCORE = Object.addProperties({
+  challenge: String.addDescription("Type of the challenge."),
+  attempts: Integer.addDescription("Every graded submission, valid or not."),
+  bestScore: Integer.addDescription(
+    "Lowest valid score, left out if no submission was valid.",
+  ),
+  bestAt: String.addFormat("date-time").addDescription(
+    "When the best score was first reached.",
+  ),
+  lastSubmittedAt: String.addFormat("date-time"),
+}).addRequired(["challenge", "attempts", "lastSubmittedAt"]);
+
+export const ADMIN_MEMBER_SCORES_ENDPOINT = PathItem.addSummary(
+  "Best score of a candidate for every challenge they submitted.",
+).addMethod({
+  get: Operation.addSecurity(INTERVIEWER)
+    .addParameters([MEMBER_ID_PARAMETER])
+    .addResponses(
+      Responses({
+        "200": Response.addDescription(
+          "Best score per challenge, sorted by challenge.",
+        ).addContents({
+          "application/json": MediaType.addSchema(Array.addItems(BEST_SCORE)),
+        }),
+        "404": NOT_FOUND_RESPONSE,
+        ...ERROR_RESPONSES,
+      }),
+    ),
+});
+
+const SCORE_HISTORY_ITEM = Object.addProperties({
+  challenge: String.addDescription("Type of the challenge."),
+  valid: Boolean,
+  score: Integer.addDescription("Left out for invalid submissions."),
+  challengeVersion: Integer,
+  latencyP50Ms: Integer.addDescription(
+    "Median time the ngrok server took to respond to our GET requests, only set for valid ngrok submissions.",
+  ),
+  latencyP95Ms: Integer.addDescription(
+    "95th percentile time the ngrok server took to respond to our GET requests, only set for valid ngrok submissions.",
+  ),
+  submittedAt: String.addFormat("date-time"),
+}).addRequired(["challenge", "valid", "challengeVersion", "submittedAt"]);
+
+export const ADMIN_MEMBER_HISTORY_ENDPOINT = PathItem.addSummary(
+  "Every graded submission of a candidate.",
+).addMethod({
+  get: Operation.addSecurity(INTERVIEWER)
+    .addParameters([MEMBER_ID_PARAMETER])
+    .addResponses(
+      Responses({
+        "200": Response.addDescription("Submissions, newest first.").addContents(
+          {
+            "application/json": MediaType.addSchema(
+              Array.addItems(SCORE_HISTORY_ITEM),
+            ),
+          },
+        ),
+        "404": NOT_FOUND_RESPONSE,
+        ...ERROR_RESPONSES,
+      }),
+    ),
+});
+
+const FRONTEND_USAGE = Object.addProperties({
+  requests: Integer.addDescription(
+    "Requests made to the frontend challenge data endpoint.",
+  ),
+  firstRequestAt: String.addFormat("date-time").addDescription(
+    "Left out if no requests were made.",
+  ),
+  lastRequestAt: String.addFormat("date-time").addDescription(
+    "Left out if no requests were made.",
+  ),
+}).addRequired(["requests"]);
+
+export const ADMIN_MEMBER_FRONTEND_USAGE_ENDPOINT = PathItem.addSummary(
+  "How a candidate used the frontend challenge data endpoint.",
+).addMethod({
+  get: Operation.addSecurity(INTERVIEWER)
+    .addParameters([MEMBER_ID_PARAMETER])
+    .addResponses(
+      Responses({
+        "200": Response.addDescription("Frontend usage stats.").addContents({
+          "application/json": MediaType.addSchema(FRONTEND_USAGE),
+        }),
+        "404": NOT_FOUND_RESPONSE,
+        ...ERROR_RESPONSES,
+      }),
+    ),
+});
+
+export const ADMIN_MEMBER_ATTEMPTS_ENDPOINT = PathItem.addSummary(
+  "Give a candidate back every attempt at a challenge.",
+).addMethod({
+  delete: Operation.addSecurity(ADMIN)
+    .addParameters([
+      MEMBER_ID_PARAMETER,
+      Parameter.schema
+        .addIn("path")
+        .addRequired(true)
+        .addName("challenge")
+        .addDescription("Type of the challenge, see /api/v1/challenges.")
+        .addSchema(String),
+    ])
+    .addResponses(
+      Responses({
+        "204": Response.addDescription("Attempts were reset."),
+        "404": Response.addDescription(
+          "Candidate or challenge not found.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+        ...ERROR_RESPONSES,
+      }),
+    ),
+});
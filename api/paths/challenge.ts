@@ -13,9 +13,19 @@ import {
   Responses,
   String,
 } from "fluid-oas";
-import { ALIEN_INVASION, ALIEN_INVASION_ANSWER } from "../schema/alien";
+import {
+  ALIEN_INVASION,
+  ALIEN_INVASION_ANSWER,
+  ALIEN_INVASION_REPLAY,
+  ALIEN_INVASION_WAVE_RESULT,
+} from "../schema/alien";
 import { ERROR, UUID } from "../schema";
-import { NGROK_URL_SUBMISSION } from "./ngrok.ts";
+import {
+  NGROK_JOB_ID_PARAMETER,
+  NGROK_JOB_RESPONSE,
+  NGROK_SUBMIT_RESPONSE,
+  NGROK_URL_SUBMISSION,
+} from "./ngrok.ts";
 
 const ID_PARAMETER = Parameter.schema
   .addIn("path")
@@ -36,6 +46,11 @@ export const ALIEN_CHALLENGE_ENDPOINT = PathItem.addMethod({
       ).addContents({
         "application/json": MediaType.addSchema(ERROR),
       }),
+      "403": Response.addDescription(
+        "The challenge is not part of your cohort, or your cohort is not open right now.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
       "404": Response.addDescription("ID not found.").addContents({
         "application/json": MediaType.addSchema(ERROR),
       }),
@@ -46,11 +61,95 @@ export const ALIEN_CHALLENGE_ENDPOINT = PathItem.addMethod({
   ),
 });
 
-export const SUBMIT_RESPONSE = Object.addProperties({
+const CHALLENGE_ID_PARAMETER = Parameter.schema
+  .addIn("path")
+  .addRequired(true)
+  .addName("challengeId")
+  .addSchema(UUID.addDescription("Unique identifier for the challenge."));
+
+export const REPLAY_ENDPOINT = PathItem.addMethod({
+  get: Operation.addSummary(
+    "Replay commands against a single wave, returning every intermediate state.",
+  )
+    .addParameters([
+      ID_PARAMETER,
+      CHALLENGE_ID_PARAMETER,
+      Parameter.schema
+        .addIn("query")
+        .addName("commands")
+        .addDescription(
+          "Comma separated list of commands to run, e.g. volley,focusedShot.",
+        )
+        .addStyle("form")
+        .addExplode(false)
+        .addSchema(
+          Array.addItems(
+            String.addEnums(["volley", "focusedShot", "focusedVolley"]),
+          ),
+        ),
+    ])
+    .addResponses(
+      Responses({
+        "200": Response.addDescription(
+          "Successfully replayed the commands.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ALIEN_INVASION_REPLAY),
+        }),
+        "400": Response.addDescription("Malformed commands.").addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+        "403": Response.addDescription(
+          "The challenge is not part of your cohort, or your cohort is not open right now.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+        "404": Response.addDescription(
+          "ID or challenge ID not found.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+        "500": Response.addDescription("Internal Server Error.").addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+      }),
+    ),
+});
+
+export const SOLUTION_ENDPOINT = PathItem.addMethod({
+  get: Operation.addSummary(
+    "Optimal commands and final state for each wave, available once your cohort has closed.",
+  )
+    .addParameters([ID_PARAMETER])
+    .addResponses(
+      Responses({
+        "200": Response.addDescription(
+          "Successfully revealed the oracle solution.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ALIEN_INVASION_ANSWER),
+        }),
+        "403": Response.addDescription(
+          "Your cohort has not closed yet, or the challenge is not part of your cohort.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+        "404": Response.addDescription("ID not found.").addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+        "500": Response.addDescription("Internal Server Error.").addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+      }),
+    ),
+});
+
+export const ALIEN_SUBMIT_RESPONSE = Object.addProperties({
   valid: Boolean,
   score: Integer,
   message: String,
-}).addRequired(["valid", "message"]);
+  waves: Array.addItems(ALIEN_INVASION_WAVE_RESULT).addDescription(
+    "Grading breakdown for every challenge ID, sorted by challenge ID.",
+  ),
+}).addRequired(["valid", "message", "waves"]);
 
 export const SUBMIT_ENDPOINT = PathItem.addMethod({
   post: Operation.addParameters([ID_PARAMETER])
@@ -64,7 +163,7 @@ export const SUBMIT_ENDPOINT = PathItem.addMethod({
         "200": Response.addDescription(
           "Verify submission against testing server oracle.",
         ).addContents({
-          "application/json": MediaType.addSchema(SUBMIT_RESPONSE),
+          "application/json": MediaType.addSchema(ALIEN_SUBMIT_RESPONSE),
         }),
         "400": Response.addDescription("Malformed Submission").addContents({
           "application/json": MediaType.addSchema(ERROR),
@@ -74,6 +173,11 @@ export const SUBMIT_ENDPOINT = PathItem.addMethod({
         ).addContents({
           "application/json": MediaType.addSchema(ERROR),
         }),
+        "403": Response.addDescription(
+          "The challenge is not part of your cohort, your cohort is not open right now, you have used every attempt, or you have not verified your email yet.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
         "404": Response.addDescription("ID not found.").addContents({
           "application/json": MediaType.addSchema(ERROR),
         }),
@@ -98,14 +202,19 @@ export const NGROK_ENDPOINT = PathItem.addMethod({
     )
     .addResponses(
       Responses({
-        "200": Response.addDescription(
-          "Grade calculated by our server querying your exposed API.",
+        "202": Response.addDescription(
+          "Grading job queued, poll the job until our server has finished querying your exposed API.",
         ).addContents({
-          "application/json": MediaType.addSchema(SUBMIT_RESPONSE),
+          "application/json": MediaType.addSchema(NGROK_SUBMIT_RESPONSE),
         }),
         "400": Response.addDescription("Malformed Submission").addContents({
           "application/json": MediaType.addSchema(ERROR),
         }),
+        "403": Response.addDescription(
+          "The challenge is not part of your cohort, your cohort is not open right now, you have used every attempt, or you have not verified your email yet.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
         "429": Response.addDescription("Too Many Requests - Rate limit exceeded")
         .addContents({
           "application/json": MediaType.addSchema(ERROR),
@@ -168,6 +277,11 @@ export const ALIEN_FRONTEND_CHALLENGE_ENDPOINT = PathItem.addMethod({
       "400": Response.addDescription("Bad Request.").addContents({
         "application/json": MediaType.addSchema(ERROR),
       }),
+      "403": Response.addDescription(
+        "Your cohort is not open right now.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
       "404": Response.addDescription("ID not found.").addContents({
         "application/json": MediaType.addSchema(ERROR),
       }),
@@ -177,3 +291,28 @@ export const ALIEN_FRONTEND_CHALLENGE_ENDPOINT = PathItem.addMethod({
     }),
   ),
 });
+
+export const NGROK_JOB_ENDPOINT = PathItem.addMethod({
+  get: Operation.addSummary("Poll the status of an ngrok grading job.")
+    .addParameters([ID_PARAMETER, NGROK_JOB_ID_PARAMETER])
+    .addResponses(
+      Responses({
+        "200": Response.addDescription(
+          "Current status of the job, with the final grade once done.",
+        ).addContents({
+          "application/json": MediaType.addSchema(NGROK_JOB_RESPONSE),
+        }),
+        "403": Response.addDescription(
+          "The challenge is not part of your cohort, or your cohort is not open right now.",
+        ).addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+        "404": Response.addDescription("ID or job ID not found.").addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+        "500": Response.addDescription("Internal Server Error.").addContents({
+          "application/json": MediaType.addSchema(ERROR),
+        }),
+      }),
+    ),
+});
@@ -0,0 +1,33 @@
+import {
+  Array,
+  MediaType,
+  Object,
+  Operation,
+  PathItem,
+  Response,
+  Responses,
+  String,
+} from "fluid-oas";
+
+const CHALLENGE_SUMMARY = Object.addProperties({
+  type: String.addDescription(
+    "Unique name of the challenge, used to tell scores for different challenges apart.",
+  ),
+  title: String.addDescription("Name of the challenge."),
+}).addRequired(["type", "title"]);
+
+export const CHALLENGES_ENDPOINT = PathItem.addSummary(
+  "List every challenge you are scored on.",
+).addMethod({
+  get: Operation.addResponses(
+    Responses({
+      "200": Response.addDescription(
+        "Every challenge, in the order they are listed in the specification.",
+      ).addContents({
+        "application/json": MediaType.addSchema(
+          Array.addItems(CHALLENGE_SUMMARY),
+        ),
+      }),
+    }),
+  ),
+});
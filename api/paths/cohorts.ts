@@ -0,0 +1,45 @@
+import {
+  Array,
+  Boolean,
+  MediaType,
+  Object,
+  Operation,
+  PathItem,
+  Response,
+  Responses,
+  String,
+} from "fluid-oas";
+import { ERROR } from "../schema";
+
+const COHORT = Object.addProperties({
+  name: String.addDescription(
+    "Name of the cohort, pass it when registering if several cohorts are open.",
+  ).addExample("Fall 2025"),
+  opensAt: String.addFormat("date-time").addDescription(
+    "When registration, challenges and submissions open.",
+  ),
+  closesAt: String.addFormat("date-time").addDescription(
+    "When registration, challenges and submissions close, left out if the cohort never closes.",
+  ),
+  challenges: Array.addItems(String).addDescription(
+    "Types of the challenges members of the cohort are scored on.",
+  ),
+  open: Boolean.addDescription("Whether the cohort is open right now."),
+}).addRequired(["name", "opensAt", "challenges", "open"]);
+
+export const COHORTS_ENDPOINT = PathItem.addSummary(
+  "List every cohort you can register into.",
+).addMethod({
+  get: Operation.addResponses(
+    Responses({
+      "200": Response.addDescription(
+        "Every cohort, the ones that open first come first.",
+      ).addContents({
+        "application/json": MediaType.addSchema(Array.addItems(COHORT)),
+      }),
+      "500": Response.addDescription("Internal server error.").addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+    }),
+  ),
+});
@@ -0,0 +1,79 @@
+import {
+  Array,
+  Integer,
+  MediaType,
+  Object,
+  Operation,
+  Parameter,
+  PathItem,
+  Response,
+  Responses,
+  String,
+} from "fluid-oas";
+import { ERROR } from "../schema";
+
+const LEADERBOARD_ENTRY = Object.addProperties({
+  rank: Integer.addDescription("Position on the leaderboard, starting at 1."),
+  handle: String.addDescription(
+    "Pseudonym of the member, the same on every leaderboard.",
+  ).addExample("brave-comet-4821"),
+  score: Integer.addDescription(
+    "Best valid score of the member, lower is better.",
+  ),
+  attempts: Integer.addDescription(
+    "Every graded submission of the member to the challenge, valid or not.",
+  ),
+  bestAt: String.addFormat("date-time").addDescription(
+    "When the best score was first reached, the earliest wins ties.",
+  ),
+}).addRequired(["rank", "handle", "score", "attempts", "bestAt"]);
+
+const LEADERBOARD = Object.addProperties({
+  challenge: String.addDescription("Type of the challenge."),
+  challengeVersion: Integer.addDescription(
+    "Version of the challenge that members are ranked at, only scores of this version count.",
+  ),
+  generatedAt: String.addFormat("date-time").addDescription(
+    "When the leaderboard was computed, it is refreshed periodically rather than on every request.",
+  ),
+  entries: Array.addItems(LEADERBOARD_ENTRY).addDescription(
+    "The best members, best first.",
+  ),
+}).addRequired(["challenge", "challengeVersion", "generatedAt", "entries"]);
+
+export const LEADERBOARD_ENDPOINT = PathItem.addSummary(
+  "Best valid score of every member at a challenge, without revealing who they are.",
+).addMethod({
+  get: Operation.addParameters([
+    Parameter.schema
+      .addIn("query")
+      .addName("challenge")
+      .addRequired(true)
+      .addDescription(
+        "Type of the challenge to rank members at, see /api/v1/challenges.",
+      )
+      .addSchema(String.addExample("algorithm")),
+    Parameter.schema
+      .addIn("query")
+      .addName("cohort")
+      .addDescription(
+        "Only rank members of this cohort, at the cohort's challenge version. Without it, members of every cohort are ranked at the current challenge version.",
+      )
+      .addSchema(String),
+  ]).addResponses(
+    Responses({
+      "200": Response.addDescription("The leaderboard.").addContents({
+        "application/json": MediaType.addSchema(LEADERBOARD),
+      }),
+      "400": Response.addDescription("Unknown challenge.").addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+      "404": Response.addDescription("Cohort not found.").addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+      "500": Response.addDescription("Internal server error.").addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+    }),
+  ),
+});
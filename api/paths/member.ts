@@ -1,49 +1,194 @@
 import {
+  Array,
+  Boolean,
+  Integer,
   MediaType,
+  Object,
   Operation,
   Parameter,
   PathItem,
   Response,
+  RequestBody,
   Responses,
+  String,
 } from "fluid-oas";
-import { EMAIL, ERROR, ID_RESPONSE, NUID } from "../schema";
+import {
+  CODE_SENT,
+  ERROR,
+  ID_RESPONSE,
+  MEMBER_DETAILS,
+  UUID,
+  VERIFICATION_DETAILS,
+} from "../schema";
 
-export const MEMBER_ENDPOINT = PathItem.addSummary(
-  "Get the associated id from the northeastern email address and nuid incase you forgot.",
+export const MEMBER_CODE_ENDPOINT = PathItem.addSummary(
+  "Email a new one-time code, if your code expired or you forgot your id.",
 ).addMethod({
-  get: Operation.addParameters([
-    Parameter.schema
-      .addIn("query")
-      .addName("email")
-      .addDescription("Northeastern email address.")
-      .addRequired(true)
-      .addSchema(EMAIL),
-    Parameter.schema
-      .addIn("query")
-      .addName("nuid")
-      .addDescription("Northeastern NUID")
-      .addRequired(true)
-      .addSchema(NUID),
-  ]).addResponses(
+  post: Operation.addRequestBody(
+    RequestBody.addContents({
+      "application/json": MediaType.addSchema(MEMBER_DETAILS),
+    }),
+  ).addResponses(
     Responses({
-      "200": Response.addDescription(
-        "Successfully retrieved id from email and nuid",
+      "202": Response.addDescription(
+        "A new code was emailed to you, any earlier code no longer works.",
       ).addContents({
-        "application/json": MediaType.addSchema(ID_RESPONSE),
+        "application/json": MediaType.addSchema(CODE_SENT),
       }),
-
       "400": Response.addDescription(
         "Invalid northeastern email address or nuid provided.",
       ).addContents({
         "application/json": MediaType.addSchema(ERROR),
       }),
+      "404": Response.addDescription(
+        "Northeastern email and nuid not found, please register.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+      "429": Response.addDescription(
+        "A code was sent moments ago, wait before asking for another.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+      "500": Response.addDescription("Internal server error.").addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+    }),
+  ),
+});
 
+export const MEMBER_VERIFY_ENDPOINT = PathItem.addSummary(
+  "Verify your Northeastern email with the code we sent, and get your id.",
+).addMethod({
+  post: Operation.addRequestBody(
+    RequestBody.addContents({
+      "application/json": MediaType.addSchema(VERIFICATION_DETAILS),
+    }),
+  ).addResponses(
+    Responses({
+      "200": Response.addDescription(
+        "Verified, you can now submit to challenges with your id.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ID_RESPONSE),
+      }),
+      "400": Response.addDescription(
+        "Invalid email address, nuid, or code, or the code expired.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
       "404": Response.addDescription(
-        "Northeastern email and nuid not found, please reregister again.",
+        "Northeastern email and nuid not found, please register.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+      "429": Response.addDescription(
+        "Too many wrong codes, ask for a new code with /api/v1/member/code.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+      "500": Response.addDescription("Internal server error.").addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+    }),
+  ),
+});
+
+const ATTEMPT_QUOTA = Object.addProperties({
+  challenge: String.addDescription(
+    "Type of the challenge, see /api/v1/challenges.",
+  ),
+  used: Integer.addMinimum(0).addDescription(
+    "Submissions counted against your limit so far.",
+  ),
+  limit: Integer.addMinimum(1).addDescription(
+    "Submissions you are allowed in total, left out if there is no limit.",
+  ),
+  remaining: Integer.addMinimum(0).addDescription(
+    "Submissions you have left, left out if there is no limit.",
+  ),
+}).addRequired(["challenge", "used"]);
+
+export const MEMBER_ATTEMPTS_ENDPOINT = PathItem.addSummary(
+  "How many submissions you have used and have left for every challenge in your cohort.",
+).addMethod({
+  get: Operation.addParameters([
+    Parameter.schema
+      .addIn("path")
+      .addRequired(true)
+      .addName("id")
+      .addSchema(UUID),
+  ]).addResponses(
+    Responses({
+      "200": Response.addDescription(
+        "Attempts for every challenge in your cohort, in the order of /api/v1/challenges.",
       ).addContents({
+        "application/json": MediaType.addSchema(Array.addItems(ATTEMPT_QUOTA)),
+      }),
+      "404": Response.addDescription("ID not found.").addContents({
         "application/json": MediaType.addSchema(ERROR),
       }),
+      "500": Response.addDescription("Internal server error.").addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+    }),
+  ),
+});
+
+const CHALLENGE_STATUS = Object.addProperties({
+  challenge: String.addDescription(
+    "Type of the challenge, see /api/v1/challenges.",
+  ),
+  title: String.addDescription("Name of the challenge."),
+  maxScore: Integer.addMinimum(0).addDescription(
+    "Score of a valid submission that makes no progress at all, scores are how far a submission is from the best score of 0.",
+  ),
+  attempts: Integer.addMinimum(0).addDescription(
+    "Submissions counted against your limit so far, the same as used in /api/v1/member/{id}/attempts.",
+  ),
+  bestScore: Integer.addDescription(
+    "Lowest valid score, left out if no submission was valid.",
+  ),
+  latestValid: Boolean.addDescription(
+    "Whether the latest submission was valid, left out if nothing was graded yet.",
+  ),
+  latestScore: Integer.addDescription(
+    "Score of the latest submission, left out if it was invalid or nothing was graded yet.",
+  ),
+  lastSubmittedAt: String.addFormat("date-time").addDescription(
+    "When the latest submission was graded, left out if nothing was graded yet.",
+  ),
+}).addRequired(["challenge", "title", "maxScore", "attempts"]);
+
+const MEMBER_STATUS = Object.addProperties({
+  cohort: String.addDescription("Name of the cohort you registered into."),
+  submissionTokens: Integer.addMinimum(0).addDescription(
+    "Submissions you can make right now before being rate limited. Every challenge shares them, and they refill over time.",
+  ),
+  frontendFetched: Boolean.addDescription(
+    "Whether you have requested the frontend challenge data. Requests are recorded in batches, so it can take up to 15 seconds to update.",
+  ),
+  challenges: Array.addItems(CHALLENGE_STATUS).addDescription(
+    "Every challenge in your cohort, in the order of /api/v1/challenges.",
+  ),
+}).addRequired(["cohort", "submissionTokens", "frontendFetched", "challenges"]);
 
+export const MEMBER_STATUS_ENDPOINT = PathItem.addSummary(
+  "Everything you have submitted so far, for every challenge in your cohort.",
+).addMethod({
+  get: Operation.addParameters([
+    Parameter.schema
+      .addIn("path")
+      .addRequired(true)
+      .addName("id")
+      .addSchema(UUID),
+  ]).addResponses(
+    Responses({
+      "200": Response.addDescription("Your progress.").addContents({
+        "application/json": MediaType.addSchema(MEMBER_STATUS),
+      }),
+      "404": Response.addDescription("ID not found.").addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
       "500": Response.addDescription("Internal server error.").addContents({
         "application/json": MediaType.addSchema(ERROR),
       }),
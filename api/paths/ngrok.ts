@@ -1,10 +1,85 @@
 import {
+  Array,
+  Boolean,
+  Integer,
+  Parameter,
   String,
   Object,
 } from "fluid-oas";
+import { UUID } from "../schema";
 
 
 export const NGROK_URL_SUBMISSION = Object.addProperties({
   url: String
     .addFormat("uri")
-});
\ No newline at end of file
+});
+
+const ALIEN_ID_SAMPLE = Array.addItems(String).addMaxItems(5);
+
+// Result of a single request our grader sent to the submitted server.
+export const NGROK_CHECK_RESULT = Object.addProperties({
+  name: String.addDescription("Name of the check."),
+  method: String.addDescription("HTTP method that was sent."),
+  path: String.addDescription("Path and query that was requested."),
+  pointsEarned: Integer,
+  pointsPossible: Integer,
+  statusCode: Integer.addDescription(
+    "Status code returned by your server, not set if it never responded.",
+  ),
+  latencyMs: Integer.addDescription("Time taken to get a response."),
+  error: String.addDescription("Why the request failed, only set on failure."),
+  expectedCount: Integer.addDescription(
+    "Number of aliens we expected back, only set for checks comparing aliens.",
+  ),
+  actualCount: Integer.addDescription(
+    "Number of aliens your server returned, only set for checks comparing aliens.",
+  ),
+  distance: Integer.addDescription(
+    "Number of aliens that were missing, unexpected, or different.",
+  ),
+  missingIDs: ALIEN_ID_SAMPLE.addDescription(
+    "Sample of alien IDs we expected but were not returned.",
+  ),
+  unexpectedIDs: ALIEN_ID_SAMPLE.addDescription(
+    "Sample of alien IDs that were returned but not expected.",
+  ),
+  mismatchedIDs: ALIEN_ID_SAMPLE.addDescription(
+    "Sample of alien IDs that were returned with different values than expected.",
+  ),
+}).addRequired(["name", "method", "path", "pointsEarned", "pointsPossible", "latencyMs"]);
+
+const NGROK_JOB_ID = UUID.addDescription("Unique identifier for the grading job.");
+
+const NGROK_JOB_STATUS = String.addEnums(["pending", "running", "done", "failed"])
+  .addDescription("Grading jobs start pending, and are done once your score has been recorded.");
+
+export const NGROK_JOB_ID_PARAMETER = Parameter.schema
+  .addIn("path")
+  .addRequired(true)
+  .addName("jobId")
+  .addSchema(NGROK_JOB_ID);
+
+export const NGROK_SUBMIT_RESPONSE = Object.addProperties({
+  jobId: NGROK_JOB_ID,
+  status: NGROK_JOB_STATUS,
+}).addRequired(["jobId", "status"]);
+
+export const NGROK_JOB_RESPONSE = Object.addProperties({
+  jobId: NGROK_JOB_ID,
+  status: NGROK_JOB_STATUS,
+  valid: Boolean.addDescription("Whether your server could be graded, only set once done."),
+  score: Integer.addDescription("Your score, only set once done and valid."),
+  latencyP50Ms: Integer.addDescription(
+    "Median time taken to respond to our GET requests, only set once done and valid.",
+  ),
+  latencyP95Ms: Integer.addDescription(
+    "95th percentile time taken to respond to our GET requests, only set once done and valid.",
+  ),
+  latencyPoints: Integer.addDescription(
+    "Points earned (positive) or lost (negative) for how fast your server responded, already included in your score.",
+  ),
+  message: String,
+  checks: Array.addItems(NGROK_CHECK_RESULT).addDescription(
+    "Every check we ran against your server, in the order they were sent.",
+  ),
+}).addRequired(["jobId", "status", "message", "checks"]);
@@ -6,10 +6,10 @@ import {
   Response,
   Responses,
 } from "fluid-oas";
-import { ERROR, ID_RESPONSE, MEMBER_DETAILS } from "../schema";
+import { CODE_SENT, ERROR, MEMBER_DETAILS } from "../schema";
 
 export const REGISTER_ENDPOINT = PathItem.addSummary(
-  "Register your Northeastern email address and grab your token",
+  "Register your Northeastern email address, we email you a code to verify it with.",
 ).addMethod({
   post: Operation.addRequestBody(
     RequestBody.addContents({
@@ -17,18 +17,31 @@ export const REGISTER_ENDPOINT = PathItem.addSummary(
     }),
   ).addResponses(
     Responses({
-      "201": Response.addDescription(
-        "Successfully registered your Northeastern email",
+      "202": Response.addDescription(
+        "Registered, verify your Northeastern email with the code we sent to get your id. Registering again before verifying sends a new code.",
       ).addContents({
-        "application/json": MediaType.addSchema(ID_RESPONSE),
+        "application/json": MediaType.addSchema(CODE_SENT),
       }),
       "400": Response.addDescription(
         "Invalid northeastern email address or nuid provided.",
       ).addContents({
         "application/json": MediaType.addSchema(ERROR),
       }),
+      "403": Response.addDescription(
+        "Registration for the cohort is closed.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+      "404": Response.addDescription("Cohort not found.").addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
       "409": Response.addDescription(
-        "User has already been registered.",
+        "User has already been registered into the cohort and verified, ask for a new code with /api/v1/member/code if you lost your id.",
+      ).addContents({
+        "application/json": MediaType.addSchema(ERROR),
+      }),
+      "429": Response.addDescription(
+        "A code was sent moments ago, wait before asking for another.",
       ).addContents({
         "application/json": MediaType.addSchema(ERROR),
       }),
@@ -1,4 +1,4 @@
-import { Array, Integer, Object, String } from "fluid-oas";
+import { Array, Boolean, Integer, Object, String } from "fluid-oas";
 import { UUID } from ".";
 
 // ALIEN INVASION API RESPONSE
@@ -27,3 +27,61 @@ export const ALIEN_INVASION_ANSWER = Array.addItems(
     }).addRequired(["remainingHP", "remainingAliens", "commands"]),
   }).addRequired(["state"]),
 );
+
+// Per wave breakdown of how a submission was graded.
+export const ALIEN_INVASION_WAVE_RESULT = Object.addProperties({
+  challengeID: UUID.addDescription("Unique identifier for the challenge."),
+  valid: Boolean.addDescription(
+    "Whether the submission for this wave was accepted.",
+  ),
+  reason: String.addDescription(
+    "Why the submission for this wave was rejected, only set when invalid.",
+  ),
+  state: Object.addProperties({
+    remainingHP: Integer,
+    remainingAliens: Integer,
+    commandsUsed: Integer,
+  })
+    .addDescription(
+      "Final state after running the submitted commands through our simulator.",
+    )
+    .addRequired(["remainingHP", "remainingAliens", "commandsUsed"]),
+  hpScore: Integer.addDescription(
+    "Absolute difference between the optimal and submitted remaining HP.",
+  ),
+  alienScore: Integer.addDescription(
+    "Absolute difference between the optimal and submitted remaining aliens.",
+  ),
+  commandScore: Integer.addDescription(
+    "Absolute difference between the optimal and submitted number of commands.",
+  ),
+}).addRequired(["challengeID", "valid"]);
+
+// Step by step trace of the invasion simulator.
+export const ALIEN_INVASION_REPLAY_STEP = Object.addProperties({
+  command: String.addEnums(["volley", "focusedShot", "focusedVolley"])
+    .addDescription(
+      "Command issued during this step, absent for the initial state.",
+    ),
+  hitAliens: Array.addItems(Integer).addDescription(
+    "Indices into the previous step's aliens that took damage.",
+  ),
+  killedAliens: Array.addItems(Integer).addDescription(
+    "Indices into the previous step's aliens that were killed.",
+  ),
+  aliens: Array.addItems(
+    Object.addProperties({
+      hp: Integer,
+      atk: Integer,
+    }).addRequired(["hp", "atk"]),
+  ).addDescription("Remaining aliens, in the order the simulator sorts them."),
+  hp: Integer.addDescription("Remaining HP after the aliens attack."),
+}).addRequired(["hitAliens", "killedAliens", "aliens", "hp"]);
+
+export const ALIEN_INVASION_REPLAY = Object.addProperties({
+  challengeID: UUID.addDescription("Unique identifier for the challenge."),
+  steps: Array.addItems(ALIEN_INVASION_REPLAY_STEP),
+  message: String.addDescription(
+    "Set when the invasion ended before every command could be executed.",
+  ),
+}).addRequired(["challengeID", "steps"]);
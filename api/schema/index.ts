@@ -45,11 +45,33 @@ export const NUID = String.addFormat("nuid")
   .addDescription("Valid nuid, must be 9 digits in length")
   .addExample("123456789");
 
+export const COHORT_NAME = String.addDescription(
+  "Name of the cohort, only needed when several cohorts are open.",
+).addExample("Fall 2025");
+
 export const MEMBER_DETAILS = Object.addProperties({
   email: EMAIL,
   nuid: NUID,
+  cohort: COHORT_NAME,
 }).addRequired(["email", "nuid"]);
 
+export const VERIFICATION_DETAILS = Object.addProperties({
+  email: EMAIL,
+  nuid: NUID,
+  cohort: COHORT_NAME,
+  code: String.addDescription(
+    "One-time code emailed to you when you registered or asked for a new code.",
+  ).addExample("042917"),
+}).addRequired(["email", "nuid", "code"]);
+
+export const CODE_SENT = Object.addProperties({
+  message: String,
+})
+  .addDescription(
+    "A one-time code was emailed to you, exchange it for your id with /api/v1/member/verify.",
+  )
+  .addRequired(["message"]);
+
 export const ID_RESPONSE = Object.addProperties({
   id: String.addFormat("uuid"),
 })
@@ -0,0 +1,103 @@
+// Grades a server the same way ngrok submissions are graded, without touching the database.
+//
+// Usage:
+//
+//	go run ./cmd/grade -member <member UUID> [-url http://localhost:8080] [-version N] [-stress=false]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/transactions"
+	"log/slog"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func main() {
+	memberFlag := flag.String("member", "", "UUID of the member whose challenge to grade against (required)")
+	urlFlag := flag.String("url", "http://localhost:8080", "Base URL of the server to grade")
+	version := flag.Int("version", services.CURRENT_CHALLENGE_VERSION, "Challenge version the member registered with")
+	// Defaults match the server's, see services.CreateNgrokLatencyConfig.
+	samples := flag.Int("latency-samples", services.NGROK_DEFAULT_LATENCY_SAMPLES, "Number of extra times every GET is sent to measure latency")
+	fastP95 := flag.Duration("fast-p95", services.NGROK_DEFAULT_FAST_P95, "p95 latency at or under which the bonus is earned")
+	slowP95 := flag.Duration("slow-p95", services.NGROK_DEFAULT_SLOW_P95, "p95 latency over which the penalty is applied")
+	bonus := flag.Int("latency-bonus", services.NGROK_DEFAULT_LATENCY_BONUS, "Points earned for a fast server")
+	penalty := flag.Int("latency-penalty", services.NGROK_DEFAULT_LATENCY_PENALTY, "Points lost for a slow server")
+	stress := flag.Bool("stress", true, "Grade the stress round of versions that have one")
+	flag.Parse()
+
+	memberID, err := uuid.Parse(*memberFlag)
+	if err != nil {
+		exit("Invalid -member: %s", err)
+	}
+	serverURL, err := url.Parse(*urlFlag)
+	if err != nil || serverURL.Scheme == "" || serverURL.Host == "" {
+		exit("Invalid -url: %q", *urlFlag)
+	}
+	if _, ok := services.GetChallengeGenerators(*version); !ok {
+		exit("Unknown -version: %d", *version)
+	}
+
+	// Grading never reads or writes the database, so no connection is needed.
+	logger := slog.New(slog.Default().Handler())
+	challengeService := services.CreateChallengeService(logger, transactions.CreateChallengeTransactions(logger, nil),
+		services.NgrokLatencyConfig{Samples: *samples, FastP95: *fastP95, SlowP95: *slowP95, Bonus: *bonus, Penalty: *penalty}, *stress)
+
+	ctx := context.Background()
+	if ok, err := challengeService.HealthCheck(ctx, *serverURL); err != nil || !ok {
+		exit("%s (GET %s/healthcheck)", services.NGROK_HEALTH_CHECK_MESSAGE, serverURL)
+	}
+
+	challenge := challengeService.GenerateUniqueNgrokChallenge(memberID, *version)
+	score := challengeService.GradeNgrokServer(ctx, *serverURL, challenge)
+	printScore(score)
+	if !score.Valid {
+		os.Exit(1)
+	}
+}
+
+func printScore(score services.NgrokChallengeScore) {
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "CHECK\tREQUEST\tPOINTS\tSTATUS\tLATENCY\tDISTANCE\tERROR")
+	earned, possible := 0, 0
+	for _, result := range score.Results {
+		earned += result.PointsEarned
+		possible += result.PointsPossible
+
+		status, distance, errMessage := "-", "-", ""
+		if result.StatusCode != 0 {
+			status = fmt.Sprint(result.StatusCode)
+		}
+		if result.Compared {
+			distance = fmt.Sprint(result.Distance)
+		}
+		if result.Err != nil {
+			errMessage = result.Err.Error()
+		}
+		fmt.Fprintf(table, "%s\t%s %s\t%d/%d\t%s\t%s\t%s\t%s\n", result.Name, result.Method, result.Path,
+			result.PointsEarned, result.PointsPossible, status, result.Latency.Round(time.Millisecond), distance, errMessage)
+	}
+	table.Flush()
+	fmt.Println()
+
+	if !score.Valid {
+		fmt.Printf("Invalid submission: %s\n", score.Reason)
+		return
+	}
+	fmt.Printf("Points: %d/%d\n", earned, possible)
+	fmt.Printf("Latency: p50 %s, p95 %s over %d responses (%+d points)\n", score.Latency.P50.Round(time.Millisecond),
+		score.Latency.P95.Round(time.Millisecond), score.Latency.Samples, score.Latency.Points)
+	fmt.Printf("Score: %d (points lost, lower is better)\n", score.Score)
+}
+
+func exit(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(2)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Writes the reports in an export format, with a result for each of the challenge types of every candidate.
+type exporter func(out io.Writer, reports []candidateReport, challengeTypes []string) error
+
+var exporters = map[string]exporter{
+	"csv":  exportCSV,
+	"json": exportJSON,
+}
+
+// Field names match the admin API.
+type exportedChallenge struct {
+	Challenge       string     `json:"challenge"`
+	Attempts        int        `json:"attempts"`
+	BestScore       *int       `json:"bestScore,omitempty"`
+	BestAt          *time.Time `json:"bestAt,omitempty"`
+	LastSubmittedAt *time.Time `json:"lastSubmittedAt,omitempty"`
+}
+
+type exportedFrontendUsage struct {
+	Requests       int64      `json:"requests"`
+	FirstRequestAt *time.Time `json:"firstRequestAt,omitempty"`
+	LastRequestAt  *time.Time `json:"lastRequestAt,omitempty"`
+}
+
+type exportedCandidate struct {
+	ID               uuid.UUID             `json:"id"`
+	Email            string                `json:"email"`
+	Nuid             string                `json:"nuid"`
+	Cohort           string                `json:"cohort"`
+	ChallengeVersion int                   `json:"challengeVersion"`
+	RegisteredAt     time.Time             `json:"registeredAt"`
+	Challenges       []exportedChallenge   `json:"challenges"`
+	FrontendUsage    exportedFrontendUsage `json:"frontendUsage"`
+}
+
+func exportCandidate(report candidateReport, challengeTypes []string) exportedCandidate {
+	candidate := exportedCandidate{
+		ID:               report.member.ID,
+		Email:            report.member.Email,
+		Nuid:             report.member.Nuid,
+		Cohort:           report.member.Cohort.Name,
+		ChallengeVersion: report.member.ChallengeVersion,
+		RegisteredAt:     report.member.CreatedAt,
+		Challenges:       make([]exportedChallenge, len(challengeTypes)),
+		FrontendUsage: exportedFrontendUsage{
+			Requests:       report.usage.Requests,
+			FirstRequestAt: report.usage.FirstRequestAt,
+			LastRequestAt:  report.usage.LastRequestAt,
+		},
+	}
+	for idx, challengeType := range challengeTypes {
+		summary := report.challenge(challengeType)
+		challenge := exportedChallenge{Challenge: challengeType, Attempts: summary.Attempts}
+		if summary.Best != nil {
+			challenge.BestScore = &summary.Best.Score
+			challenge.BestAt = &summary.Best.CreatedAt
+		}
+		if summary.Attempts > 0 {
+			challenge.LastSubmittedAt = &summary.Latest.CreatedAt
+		}
+		candidate.Challenges[idx] = challenge
+	}
+	return candidate
+}
+
+func exportJSON(out io.Writer, reports []candidateReport, challengeTypes []string) error {
+	candidates := make([]exportedCandidate, len(reports))
+	for idx, report := range reports {
+		candidates[idx] = exportCandidate(report, challengeTypes)
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(candidates)
+}
+
+// One row per candidate, with the columns of every challenge after the registration.
+func exportCSV(out io.Writer, reports []candidateReport, challengeTypes []string) error {
+	writer := csv.NewWriter(out)
+	header := []string{"id", "email", "nuid", "cohort", "challenge_version", "registered_at"}
+	for _, challengeType := range challengeTypes {
+		header = append(header, challengeType+"_attempts", challengeType+"_best_score", challengeType+"_best_at",
+			challengeType+"_last_submitted_at")
+	}
+	header = append(header, "frontend_requests", "frontend_first_request_at", "frontend_last_request_at")
+	writer.Write(header)
+
+	for _, report := range reports {
+		candidate := exportCandidate(report, challengeTypes)
+		row := []string{candidate.ID.String(), candidate.Email, candidate.Nuid, candidate.Cohort,
+			fmt.Sprint(candidate.ChallengeVersion), csvTime(&candidate.RegisteredAt)}
+		for _, challenge := range candidate.Challenges {
+			bestScore := ""
+			if challenge.BestScore != nil {
+				bestScore = fmt.Sprint(*challenge.BestScore)
+			}
+			row = append(row, fmt.Sprint(challenge.Attempts), bestScore, csvTime(challenge.BestAt),
+				csvTime(challenge.LastSubmittedAt))
+		}
+		row = append(row, fmt.Sprint(candidate.FrontendUsage.Requests), csvTime(candidate.FrontendUsage.FirstRequestAt),
+			csvTime(candidate.FrontendUsage.LastRequestAt))
+		writer.Write(row)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Empty for times that never happened.
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCSVHasAColumnForEveryChallenge(t *testing.T) {
+	registeredAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	member := models.Member{ID: uuid.Nil, Email: "candidate@northeastern.edu", Nuid: "123456789",
+		Cohort: models.Cohort{Name: "Generate 2025"}, ChallengeVersion: services.CURRENT_CHALLENGE_VERSION, CreatedAt: registeredAt}
+	score := *models.CreateScore(member.ID, models.ALGORITHM_CHALLENGE_TYPE, 7, true, services.CURRENT_CHALLENGE_VERSION)
+	score.CreatedAt = registeredAt.Add(time.Hour)
+	report := candidateReport{
+		member:     member,
+		scores:     []models.Score{score},
+		challenges: services.SummarizeScores([]models.Score{score}),
+		usage:      models.FrontendUsageSummary{UserID: member.ID},
+	}
+
+	var out bytes.Buffer
+	err := exportCSV(&out, []candidateReport{report}, []string{models.NGROK_CHALLENGE_TYPE, models.ALGORITHM_CHALLENGE_TYPE})
+	assert.NoError(t, err)
+	assert.Equal(t, "id,email,nuid,cohort,challenge_version,registered_at,"+
+		"ngrok_attempts,ngrok_best_score,ngrok_best_at,ngrok_last_submitted_at,"+
+		"algorithm_attempts,algorithm_best_score,algorithm_best_at,algorithm_last_submitted_at,"+
+		"frontend_requests,frontend_first_request_at,frontend_last_request_at\n"+
+		"00000000-0000-0000-0000-000000000000,candidate@northeastern.edu,123456789,Generate 2025,"+
+		strconv.Itoa(services.CURRENT_CHALLENGE_VERSION)+",2025-09-01T12:00:00Z,"+
+		"0,,,,"+
+		"1,7,2025-09-01T13:00:00Z,2025-09-01T13:00:00Z,"+
+		"0,,\n", out.String())
+}
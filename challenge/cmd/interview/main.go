@@ -0,0 +1,377 @@
+// Reports on candidates for interviewers, reading straight from the challenge database.
+//
+// Usage:
+//
+//	go run ./cmd/interview summary -email <email> | -nuid <NUID>
+//	go run ./cmd/interview scores -email <email> | -nuid <NUID>
+//	go run ./cmd/interview usage -email <email> | -nuid <NUID>
+//	go run ./cmd/interview list [-cohort <name>]
+//	go run ./cmd/interview top -challenge <type> [-cohort <name>] [-version N] [-limit N]
+//	go run ./cmd/interview export [-format csv|json] [-cohort <name>] [-output <file>]
+//
+// The database is configured with DB_HOST, DB_PORT, DB_USER, DB_PASSWORD and DB_NAME like the server. Nothing is
+// ever written, so the role in interview/readonly_interviewers.sql is all that is needed.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"generate_technical_challenge_2025/internal/database"
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/transactions"
+	"generate_technical_challenge_2025/internal/utils"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sethvargo/go-envconfig"
+)
+
+const usage = `Usage: interview <command> [flags]
+
+Commands:
+  summary  Registration, best score and attempts per challenge, every score and frontend usage of a candidate
+  scores   Every score of a candidate, newest first
+  usage    How often a candidate requested the frontend challenge data
+  list     Every candidate, with their best score and attempts per challenge
+  top      Candidates with the best scores at a challenge
+  export   Every candidate as CSV or JSON
+
+Run interview <command> -h for the flags of a command.`
+
+// Only the database part of utils.EnvConfig, defaults match the production database.
+type dbConfig struct {
+	DB_HOST     string `env:"DB_HOST, required"`
+	DB_PORT     string `env:"DB_PORT, default=5432"`
+	DB_USER     string `env:"DB_USER, required"`
+	DB_PASSWORD string `env:"DB_PASSWORD"`
+	DB_NAME     string `env:"DB_NAME, default=postgres"`
+}
+
+type cli struct {
+	logger  *slog.Logger
+	out     io.Writer
+	members transactions.MemberTransactions
+	cohorts transactions.CohortTransactions
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		exit(usage)
+	}
+	commands := map[string]func(*cli, []string) error{
+		"summary": (*cli).summary,
+		"scores":  (*cli).scores,
+		"usage":   (*cli).usage,
+		"list":    (*cli).list,
+		"top":     (*cli).top,
+		"export":  (*cli).export,
+	}
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		exit(usage)
+	}
+	// Only warnings, so the reports are not buried under every query.
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	if err := command(&cli{logger: logger, out: os.Stdout}, os.Args[2:]); err != nil {
+		exit("%s", err)
+	}
+}
+
+// Connects to the database, called once the flags of the command are valid.
+func (c *cli) connect() {
+	var cfg dbConfig
+	if err := envconfig.Process(context.Background(), &cfg); err != nil {
+		exit("%s", err)
+	}
+	db := database.CreateDatabase(utils.EnvConfig{
+		DB_HOST:     cfg.DB_HOST,
+		DB_PORT:     cfg.DB_PORT,
+		DB_USER:     cfg.DB_USER,
+		DB_PASSWORD: cfg.DB_PASSWORD,
+		DB_NAME:     cfg.DB_NAME,
+	}, c.logger)
+	c.members = transactions.CreateMemberTransactions(c.logger, db)
+	c.cohorts = transactions.CreateCohortTransactions(c.logger, db)
+}
+
+// Parses the flags of a candidate report, and looks up every registration of the candidate.
+func (c *cli) findCandidate(name string, args []string) ([]models.Member, error) {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	email := flags.String("email", "", "Email the candidate registered with")
+	nuid := flags.String("nuid", "", "NUID the candidate registered with")
+	flags.Parse(args)
+	if *email == "" && *nuid == "" {
+		flags.Usage()
+		return nil, errors.New("-email, -nuid or both are required")
+	}
+
+	c.connect()
+	members, err := c.members.FindMembers(*email, *nuid)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, errors.New("candidate not found")
+	}
+	return members, nil
+}
+
+// Looks up the cohort to report on, or every cohort when name is empty. Returns the ID to filter members by,
+// and the challenges of the cohorts in the order they first appear.
+func (c *cli) findCohort(name string) (uuid.UUID, []string, error) {
+	if name != "" {
+		cohort, exists, err := c.cohorts.GetCohortByName(name)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+		if !exists {
+			return uuid.Nil, nil, fmt.Errorf("cohort %q not found", name)
+		}
+		return cohort.ID, cohort.ChallengeTypes, nil
+	}
+	cohorts, err := c.cohorts.GetCohorts()
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	var challengeTypes []string
+	for _, cohort := range cohorts {
+		for _, challengeType := range cohort.ChallengeTypes {
+			if !slices.Contains(challengeTypes, challengeType) {
+				challengeTypes = append(challengeTypes, challengeType)
+			}
+		}
+	}
+	return uuid.Nil, challengeTypes, nil
+}
+
+func (c *cli) summary(args []string) error {
+	members, err := c.findCandidate("summary", args)
+	if err != nil {
+		return err
+	}
+	reports, err := c.loadReports(members)
+	if err != nil {
+		return err
+	}
+	for idx, report := range reports {
+		if idx > 0 {
+			fmt.Fprintln(c.out)
+		}
+		fmt.Fprintf(c.out, "Email: %s\n", report.member.Email)
+		fmt.Fprintf(c.out, "NUID: %s\n", report.member.Nuid)
+		fmt.Fprintf(c.out, "Cohort: %s\n", report.member.Cohort.Name)
+		fmt.Fprintf(c.out, "Registered: %s\n", formatTime(report.member.CreatedAt))
+		fmt.Fprintln(c.out)
+
+		table := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "CHALLENGE\tATTEMPTS\tBEST\tBEST AT\tLAST SUBMITTED")
+		for _, summary := range report.challenges {
+			best, bestAt := "-", "-"
+			if summary.Best != nil {
+				best, bestAt = fmt.Sprint(summary.Best.Score), formatTime(summary.Best.CreatedAt)
+			}
+			fmt.Fprintf(table, "%s\t%d\t%s\t%s\t%s\n", summary.ChallengeType, summary.Attempts, best, bestAt,
+				formatTime(summary.Latest.CreatedAt))
+		}
+		table.Flush()
+		fmt.Fprintln(c.out)
+
+		if err := c.printScores(report.member, report.scores); err != nil {
+			return err
+		}
+		fmt.Fprintln(c.out)
+		c.printUsage(report.usage)
+	}
+	return nil
+}
+
+func (c *cli) scores(args []string) error {
+	members, err := c.findCandidate("scores", args)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		scores, err := c.members.GetScores(member.ID)
+		if err != nil {
+			return err
+		}
+		if err := c.printScores(member, scores); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prints the scores, which must be sorted newest first.
+func (c *cli) printScores(member models.Member, scores []models.Score) error {
+	fmt.Fprintf(c.out, "Scores of %s in %s:\n", member.Email, member.Cohort.Name)
+	table := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "SUBMITTED\tCHALLENGE\tSCORE\tVERSION\tLATENCY P95")
+	for _, score := range scores {
+		value, latency := "FAILED", "-"
+		if score.IsValid {
+			value = fmt.Sprint(score.Score)
+		}
+		if score.LatencyP95Ms != nil {
+			latency = fmt.Sprintf("%dms", *score.LatencyP95Ms)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%s\n", formatTime(score.CreatedAt), score.ChallengeType, value, score.ChallengeVersion, latency)
+	}
+	return table.Flush()
+}
+
+func (c *cli) usage(args []string) error {
+	members, err := c.findCandidate("usage", args)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		usage, err := c.members.GetFrontendUsageSummary(member.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "Frontend usage of %s in %s:\n", member.Email, member.Cohort.Name)
+		c.printUsage(usage)
+	}
+	return nil
+}
+
+func (c *cli) printUsage(usage models.FrontendUsageSummary) {
+	fmt.Fprintf(c.out, "Total Requests: %d\n", usage.Requests)
+	if usage.Requests == 0 {
+		fmt.Fprintln(c.out, "No frontend requests made")
+		return
+	}
+	fmt.Fprintf(c.out, "First Request: %s\n", formatTime(*usage.FirstRequestAt))
+	fmt.Fprintf(c.out, "Last Request: %s\n", formatTime(*usage.LastRequestAt))
+}
+
+func (c *cli) list(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	cohortName := flags.String("cohort", "", "Only list candidates of this cohort")
+	flags.Parse(args)
+
+	c.connect()
+	cohortID, challengeTypes, err := c.findCohort(*cohortName)
+	if err != nil {
+		return err
+	}
+	members, err := c.members.GetCohortMembers(cohortID)
+	if err != nil {
+		return err
+	}
+	reports, err := c.loadReports(members)
+	if err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprint(table, "EMAIL\tNUID\tCOHORT\tREGISTERED")
+	for _, challengeType := range challengeTypes {
+		fmt.Fprintf(table, "\t%s BEST\t%s ATTEMPTS", challengeType, challengeType)
+	}
+	fmt.Fprintln(table, "\tFRONTEND REQUESTS")
+	for _, report := range reports {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s", report.member.Email, report.member.Nuid, report.member.Cohort.Name,
+			formatTime(report.member.CreatedAt))
+		for _, challengeType := range challengeTypes {
+			summary := report.challenge(challengeType)
+			best := "-"
+			if summary.Best != nil {
+				best = fmt.Sprint(summary.Best.Score)
+			}
+			fmt.Fprintf(table, "\t%s\t%d", best, summary.Attempts)
+		}
+		fmt.Fprintf(table, "\t%d\n", report.usage.Requests)
+	}
+	return table.Flush()
+}
+
+func (c *cli) top(args []string) error {
+	flags := flag.NewFlagSet("top", flag.ExitOnError)
+	challengeType := flags.String("challenge", "", "Challenge to rank candidates at (required)")
+	cohortName := flags.String("cohort", "", "Only rank candidates of this cohort")
+	version := flags.Int("version", services.CURRENT_CHALLENGE_VERSION, "Challenge version to rank candidates at, only scores of this version count")
+	limit := flags.Int("limit", 10, "Number of candidates to show, 0 for every candidate with a valid score")
+	flags.Parse(args)
+	if *challengeType == "" {
+		flags.Usage()
+		return errors.New("-challenge is required")
+	}
+
+	c.connect()
+	cohortID, challengeTypes, err := c.findCohort(*cohortName)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(challengeTypes, *challengeType) {
+		return fmt.Errorf("%w: %s", services.ErrUnknownChallengeType, *challengeType)
+	}
+	best, err := c.members.GetBestScores(*challengeType, cohortID, *version, *limit)
+	if err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "RANK\tEMAIL\tNUID\tSCORE\tATTEMPTS\tBEST AT")
+	for idx, score := range best {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%d\t%d\t%s\n", idx+1, score.Email, score.Nuid, score.Score, score.Attempts,
+			formatTime(score.BestAt))
+	}
+	return table.Flush()
+}
+
+func (c *cli) export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	format := flags.String("format", "csv", "Format of the export, csv or json")
+	cohortName := flags.String("cohort", "", "Only export candidates of this cohort")
+	output := flags.String("output", "", "File to write the export to, instead of standard output")
+	flags.Parse(args)
+	write, ok := exporters[*format]
+	if !ok {
+		flags.Usage()
+		return fmt.Errorf("unknown -format: %q", *format)
+	}
+
+	c.connect()
+	cohortID, challengeTypes, err := c.findCohort(*cohortName)
+	if err != nil {
+		return err
+	}
+	members, err := c.members.GetCohortMembers(cohortID)
+	if err != nil {
+		return err
+	}
+	reports, err := c.loadReports(members)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return write(c.out, reports, challengeTypes)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	// Closing can fail to flush what was written, so it is reported like any other write error.
+	return errors.Join(write(file, reports, challengeTypes), file.Close())
+}
+
+// Times are shown in the interviewer's time zone.
+func formatTime(t time.Time) string {
+	return t.Local().Format(time.DateTime)
+}
+
+func exit(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(2)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// Everything interviewers look at for one registration of a candidate.
+type candidateReport struct {
+	member     models.Member
+	scores     []models.Score // Newest first.
+	challenges []services.ScoreSummary
+	usage      models.FrontendUsageSummary
+}
+
+// Summary of the challenge, with no attempts if the candidate never submitted it.
+func (r candidateReport) challenge(challengeType string) services.ScoreSummary {
+	summary, ok := lo.Find(r.challenges, func(summary services.ScoreSummary) bool {
+		return summary.ChallengeType == challengeType
+	})
+	if !ok {
+		return services.ScoreSummary{ChallengeType: challengeType}
+	}
+	return summary
+}
+
+// Loads the reports of every member with the same few queries, however many members there are.
+func (c *cli) loadReports(members []models.Member) ([]candidateReport, error) {
+	ids := lo.Map(members, func(member models.Member, _ int) uuid.UUID { return member.ID })
+	scores, err := c.members.GetScoresOfMembers(ids)
+	if err != nil {
+		return nil, err
+	}
+	usages, err := c.members.GetFrontendUsageSummaries(ids)
+	if err != nil {
+		return nil, err
+	}
+	// Grouping keeps the scores of each member newest first.
+	scoresByMember := lo.GroupBy(scores, func(score models.Score) uuid.UUID { return score.UserID })
+
+	reports := make([]candidateReport, len(members))
+	for idx, member := range members {
+		usage, ok := usages[member.ID]
+		if !ok {
+			usage = models.FrontendUsageSummary{UserID: member.ID}
+		}
+		reports[idx] = candidateReport{
+			member:     member,
+			scores:     scoresByMember[member.ID],
+			challenges: services.SummarizeScores(scoresByMember[member.ID]),
+			usage:      usage,
+		}
+	}
+	return reports, nil
+}
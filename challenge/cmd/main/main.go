@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"generate_technical_challenge_2025/internal/database"
 	"generate_technical_challenge_2025/internal/handler"
 	"generate_technical_challenge_2025/internal/server"
@@ -26,14 +27,36 @@ func main() {
 	logger.Info("Initializing transaction layer...")
 	memberTransactions := transactions.CreateMemberTransactions(logger, db)
 	challengeTransactions := transactions.CreateChallengeTransactions(logger, db)
+	cohortTransactions := transactions.CreateCohortTransactions(logger, db)
 
 	logger.Info("Intializing service layer...")
-	memberServices := services.CreateMemberService(logger, memberTransactions)
+	memberServices := services.CreateMemberService(logger, memberTransactions, env.ChallengeAttemptLimits())
 	challengeServices := services.CreateChallengeService(
-		logger, challengeTransactions)
+		logger, challengeTransactions, services.CreateNgrokLatencyConfig(env), env.NGROK_STRESS_ROUND)
+
+	challenges := services.CreateChallengeRegistry(challengeServices)
+	leaderboardServices := services.CreateLeaderboardService(logger, memberTransactions, cohortTransactions, challenges, services.CreateLeaderboardConfig(env))
+	cohortServices := services.CreateCohortService(logger, cohortTransactions, challenges.Types())
+	mailerFn := func() (services.Mailer, error) { return services.CreateMailer(env, logger) }
+	mailer := utils.FatalCall(mailerFn)
+	verificationServices := services.CreateVerificationService(logger, memberTransactions, mailer, services.CreateVerificationConfig(env))
+
+	logger.Info("Syncing cohorts...")
+	cohortsFn := func() ([]services.CohortConfig, error) { return services.CreateCohortConfigs(env) }
+	cohorts := utils.FatalCall(cohortsFn)
+	syncFn := func() error { return cohortServices.SyncCohorts(cohorts, env.DEFAULT_COHORT) }
+	utils.FatalCallErrorSupplier(syncFn)
+
+	logger.Info("Starting ngrok grading workers...")
+	workersFn := func() error {
+		return challengeServices.StartNgrokWorkers(context.Background(), env.NGROK_WORKERS, memberServices)
+	}
+	utils.FatalCallErrorSupplier(workersFn)
 
 	logger.Info("Intializing handler layer...")
-	h := handler.CreateHandler(logger, memberServices, challengeServices)
+	h := handler.CreateHandler(logger, memberServices, challengeServices, cohortServices, leaderboardServices, verificationServices, challenges)
 
-	server.RunServer(h, env, logger)
+	security := handler.CreateSecurityHandler(services.CreateStaffAuthenticator(env.INTERVIEWER_TOKENS, env.ADMIN_TOKENS))
+
+	server.RunServer(h, security, env, logger)
 }
@@ -29,7 +29,13 @@ func CreateDatabase(cfg utils.EnvConfig, logger *slog.Logger) *gorm.DB {
 
 func AutoMigrate(db *gorm.DB) {
 	// Add migrations here.
+	db.AutoMigrate(&models.Cohort{})
 	db.AutoMigrate(&models.Member{})
 	db.AutoMigrate(&models.Score{})
 	db.AutoMigrate(&models.FrontendUsage{})
+	db.AutoMigrate(&models.Submission{})
+	db.AutoMigrate(&models.SubmissionWave{})
+	db.AutoMigrate(&models.NgrokJob{})
+	db.AutoMigrate(&models.AttemptCount{})
+	db.AutoMigrate(&models.VerificationCode{})
 }
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Represents how many times a member has submitted a challenge, counted before the submission is graded so that
+// the member's attempt limit can be enforced.
+type AttemptCount struct {
+	UserID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ChallengeType string    `gorm:"primaryKey"`
+	Used          int       `gorm:"not null;default:0"`
+	UpdatedAt     time.Time
+
+	Member Member `gorm:"foreignKey:UserID;references:ID"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Represents a recruiting cycle that members register into, e.g. "Fall 2025".
+type Cohort struct {
+	ID   uuid.UUID `gorm:"primaryKey"`
+	Name string    `gorm:"not null;uniqueIndex"`
+	// Members can only register, fetch challenges and submit between these, a zero ClosesAt never closes.
+	OpensAt  time.Time `gorm:"not null"`
+	ClosesAt time.Time
+	// Types of the challenges members of the cohort are scored on, see ALGORITHM_CHALLENGE_TYPE.
+	ChallengeTypes []string `gorm:"serializer:json"`
+	// Version of the challenge generators members are registered with.
+	ChallengeVersion int `gorm:"not null;default:1"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func CreateCohort(name string, opensAt time.Time, closesAt time.Time, challengeTypes []string, challengeVersion int) *Cohort {
+	cohort := &Cohort{}
+	cohort.ID = uuid.New()
+	cohort.Name = name
+	cohort.OpensAt = opensAt
+	cohort.ClosesAt = closesAt
+	cohort.ChallengeTypes = challengeTypes
+	cohort.ChallengeVersion = challengeVersion
+	cohort.CreatedAt = time.Now()
+	cohort.UpdatedAt = time.Now()
+	return cohort
+}
+
+// Whether the given time is within the cohort's window.
+func (c Cohort) IsOpen(now time.Time) bool {
+	return !now.Before(c.OpensAt) && (c.ClosesAt.IsZero() || now.Before(c.ClosesAt))
+}
+
+// Whether members of the cohort are scored on the challenge type.
+func (c Cohort) HasChallenge(challengeType string) bool {
+	return slices.Contains(c.ChallengeTypes, challengeType)
+}
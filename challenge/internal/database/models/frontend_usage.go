@@ -11,3 +11,11 @@ type FrontendUsage struct {
 	UserID    uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
 	Timestamp time.Time `gorm:"not null;default:now()" json:"timestamp"`
 }
+
+// Aggregate of a member's frontend usage, not a table.
+type FrontendUsageSummary struct {
+	UserID         uuid.UUID
+	Requests       int64
+	FirstRequestAt *time.Time // nil when no requests were made.
+	LastRequestAt  *time.Time // nil when no requests were made.
+}
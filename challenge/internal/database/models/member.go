@@ -14,16 +14,28 @@ type Member struct {
 	Email string
 	// NUID of the user.
 	Nuid string
+	// Cohort the user registered into, the same email and NUID can register once per cohort.
+	CohortID uuid.UUID `gorm:"type:uuid;index"`
+	// Version of the challenge generators the user was registered with, so their challenges never change.
+	ChallengeVersion int `gorm:"not null;default:1"`
+	// Set until the user enters the code emailed to them. Users that registered before emails were verified
+	// stay verified, since the column defaults to false.
+	PendingVerification bool `gorm:"not null;default:false"`
 	// Metadata
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	Cohort Cohort `gorm:"foreignKey:CohortID;references:ID"`
 }
 
-func CreateMember(email string, nuid string) *Member {
+func CreateMember(email string, nuid string, cohortID uuid.UUID, challengeVersion int) *Member {
 	user := &Member{}
 	user.ID = uuid.New()
 	user.Email = email
 	user.Nuid = nuid
+	user.CohortID = cohortID
+	user.ChallengeVersion = challengeVersion
+	user.PendingVerification = true
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
 	return user
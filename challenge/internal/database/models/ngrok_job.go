@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NGROK_JOB_PENDING = "pending"
+	NGROK_JOB_RUNNING = "running"
+	NGROK_JOB_DONE    = "done"
+	NGROK_JOB_FAILED  = "failed" // The job could not be graded at all, no score is recorded.
+)
+
+// Represents a queued grading of a member's ngrok server.
+type NgrokJob struct {
+	ID               uuid.UUID  `gorm:"primaryKey"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index"`
+	URL              string     `gorm:"not null"`
+	Status           string     `gorm:"not null;index"`
+	ChallengeVersion int        `gorm:"not null;default:1"`
+	ScoreID          *uuid.UUID `gorm:"type:uuid"` // Only set once the job is done.
+	Message          string
+	Checks           []NgrokJobCheck `gorm:"serializer:json"`
+	// Latency of the member's server, only set once the job is done, see services.NgrokLatency.
+	LatencyP50Ms  int64
+	LatencyP95Ms  int64
+	LatencyPoints int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	Score  *Score `gorm:"foreignKey:ScoreID;references:ID"`
+	Member Member `gorm:"foreignKey:UserID;references:ID"`
+}
+
+// Result of a single request sent to the member's server, see services.NgrokRequestResult.
+type NgrokJobCheck struct {
+	Name           string   `json:"name"`
+	Method         string   `json:"method"`
+	Path           string   `json:"path"`
+	PointsEarned   int      `json:"pointsEarned"`
+	PointsPossible int      `json:"pointsPossible"`
+	StatusCode     int      `json:"statusCode"`
+	LatencyMs      int64    `json:"latencyMs"`
+	Error          string   `json:"error"`
+	Compared       bool     `json:"compared"`
+	ExpectedCount  int      `json:"expectedCount"`
+	ActualCount    int      `json:"actualCount"`
+	Distance       int      `json:"distance"`
+	MissingIDs     []string `json:"missingIDs"`
+	UnexpectedIDs  []string `json:"unexpectedIDs"`
+	MismatchedIDs  []string `json:"mismatchedIDs"`
+}
+
+func CreateNgrokJob(userID uuid.UUID, url string, challengeVersion int) *NgrokJob {
+	job := &NgrokJob{}
+	job.ID = uuid.New()
+	job.UserID = userID
+	job.URL = url
+	job.Status = NGROK_JOB_PENDING
+	job.ChallengeVersion = challengeVersion
+	job.Checks = []NgrokJobCheck{}
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+	return job
+}
@@ -19,20 +19,36 @@ type Score struct {
 	ChallengeType string    `gorm:"not null"`
 	Score         int       `gorm:"not null"`
 	IsValid       bool      `gorm:"not null;default:true"`
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// Version of the challenge generators the score was graded against.
+	ChallengeVersion int `gorm:"not null;default:1"`
+	// Latency of the member's ngrok server, only set for valid ngrok scores, see services.NgrokLatency.
+	LatencyP50Ms *int64
+	LatencyP95Ms *int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 
 	Member Member `gorm:"foreignKey:UserID;references:ID"`
 }
 
-func CreateScore(userID uuid.UUID, challengeType string, score int, isValid bool) *Score {
+func CreateScore(userID uuid.UUID, challengeType string, score int, isValid bool, challengeVersion int) *Score {
 	scoreRecord := &Score{}
 	scoreRecord.ID = uuid.New()
 	scoreRecord.UserID = userID
 	scoreRecord.ChallengeType = challengeType
 	scoreRecord.Score = score
 	scoreRecord.IsValid = isValid
+	scoreRecord.ChallengeVersion = challengeVersion
 	scoreRecord.CreatedAt = time.Now()
 	scoreRecord.UpdatedAt = time.Now()
 	return scoreRecord
 }
+
+// A member's best valid score at one challenge, not a table.
+type BestScore struct {
+	UserID   uuid.UUID
+	Email    string
+	Nuid     string
+	Score    int
+	Attempts int       // Every graded submission to the challenge, valid or not.
+	BestAt   time.Time // When the best score was first reached.
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Represents everything a member sent for a single algorithm challenge submission, so that it can be
+// replayed later.
+type Submission struct {
+	ID        uuid.UUID        `gorm:"primaryKey"`
+	ScoreID   uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex"`
+	UserID    uuid.UUID        `gorm:"type:uuid;not null;index"`
+	Waves     []SubmissionWave `gorm:"foreignKey:SubmissionID"`
+	CreatedAt time.Time
+
+	Score Score `gorm:"foreignKey:ScoreID;references:ID"`
+}
+
+// Represents a member's answer to one challenge wave, next to what our simulator and oracle made of it.
+type SubmissionWave struct {
+	ID           uuid.UUID `gorm:"primaryKey"`
+	SubmissionID uuid.UUID `gorm:"type:uuid;not null;index"`
+	ChallengeID  uuid.UUID `gorm:"type:uuid;not null"`
+	IsValid      bool      `gorm:"not null"`
+	Reason       string
+	// What the member sent, empty if they did not submit this wave.
+	Commands      []string `gorm:"serializer:json"`
+	ClaimedHp     *int
+	ClaimedAliens *int
+	// Result of running the member's commands, nil if they could not be run.
+	SimulatedHp     *int
+	SimulatedAliens *int
+	// Optimal play, nil if the wave does not belong to the member.
+	OracleHp       *int
+	OracleAliens   *int
+	OracleCommands []string `gorm:"serializer:json"`
+}
+
+func CreateSubmission(scoreID uuid.UUID, userID uuid.UUID, waves []SubmissionWave) *Submission {
+	submission := &Submission{}
+	submission.ID = uuid.New()
+	submission.ScoreID = scoreID
+	submission.UserID = userID
+	for idx := range waves {
+		waves[idx].SubmissionID = submission.ID
+	}
+	submission.Waves = waves
+	submission.CreatedAt = time.Now()
+	return submission
+}
+
+func CreateSubmissionWave(challengeID uuid.UUID, isValid bool, reason string) SubmissionWave {
+	wave := SubmissionWave{}
+	wave.ID = uuid.New()
+	wave.ChallengeID = challengeID
+	wave.IsValid = isValid
+	wave.Reason = reason
+	return wave
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Represents the one-time code a member was last emailed to verify their email with. Sending a new code replaces it.
+type VerificationCode struct {
+	UserID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	// Hash of the code, the code itself is only ever emailed.
+	CodeHash string `gorm:"not null"`
+	// Codes entered since this code was sent.
+	Attempts  int       `gorm:"not null;default:0"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time
+
+	Member Member `gorm:"foreignKey:UserID;references:ID"`
+}
+
+func CreateVerificationCode(userID uuid.UUID, codeHash string, expiresAt time.Time) *VerificationCode {
+	code := &VerificationCode{}
+	code.UserID = userID
+	code.CodeHash = codeHash
+	code.ExpiresAt = expiresAt
+	code.CreatedAt = time.Now()
+	return code
+}
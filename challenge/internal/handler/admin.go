@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	api "generate_technical_challenge_2025/internal/api"
+	models "generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+
+	"github.com/samber/lo"
+)
+
+// APIV1AdminMembersGet implements api.Handler.
+func (h Handler) APIV1AdminMembersGet(ctx context.Context, params api.APIV1AdminMembersGetParams) (api.APIV1AdminMembersGetRes, error) {
+	if !params.Email.Set && !params.Nuid.Set {
+		return &api.APIV1AdminMembersGetBadRequest{Message: "Look up candidates by email, NUID or both."}, nil
+	}
+	members, err := h.memberService.FindMembers(params.Email.Value, params.Nuid.Value)
+	if err != nil {
+		return &api.APIV1AdminMembersGetInternalServerError{Message: "Database error querying for candidates."}, nil
+	}
+	result := api.APIV1AdminMembersGetOKApplicationJSON(lo.Map(members, func(member models.Member, _ int) api.APIV1AdminMembersGetOKItem {
+		return api.APIV1AdminMembersGetOKItem{
+			ID:               member.ID,
+			Email:            member.Email,
+			Nuid:             member.Nuid,
+			Cohort:           member.Cohort.Name,
+			ChallengeVersion: member.ChallengeVersion,
+			RegisteredAt:     member.CreatedAt,
+		}
+	}))
+	return &result, nil
+}
+
+// APIV1AdminMembersIDScoresGet implements api.Handler.
+func (h Handler) APIV1AdminMembersIDScoresGet(ctx context.Context, params api.APIV1AdminMembersIDScoresGetParams) (api.APIV1AdminMembersIDScoresGetRes, error) {
+	exists, err := h.memberService.CheckMemberExistsById(params.ID)
+	if err != nil {
+		return &api.APIV1AdminMembersIDScoresGetInternalServerError{Message: "Database error querying for candidate."}, nil
+	}
+	if !exists {
+		return &api.APIV1AdminMembersIDScoresGetNotFound{Message: "Candidate not found."}, nil
+	}
+	summaries, err := h.memberService.GetScoreSummaries(params.ID)
+	if err != nil {
+		return &api.APIV1AdminMembersIDScoresGetInternalServerError{Message: "Database error querying for scores."}, nil
+	}
+	result := api.APIV1AdminMembersIDScoresGetOKApplicationJSON(lo.Map(summaries, func(summary services.ScoreSummary, _ int) api.APIV1AdminMembersIDScoresGetOKItem {
+		item := api.APIV1AdminMembersIDScoresGetOKItem{
+			Challenge:       summary.ChallengeType,
+			Attempts:        summary.Attempts,
+			LastSubmittedAt: summary.Latest.CreatedAt,
+		}
+		if summary.Best != nil {
+			item.BestScore = api.NewOptInt(summary.Best.Score)
+			item.BestAt = api.NewOptDateTime(summary.Best.CreatedAt)
+		}
+		return item
+	}))
+	return &result, nil
+}
+
+// APIV1AdminMembersIDHistoryGet implements api.Handler.
+func (h Handler) APIV1AdminMembersIDHistoryGet(ctx context.Context, params api.APIV1AdminMembersIDHistoryGetParams) (api.APIV1AdminMembersIDHistoryGetRes, error) {
+	exists, err := h.memberService.CheckMemberExistsById(params.ID)
+	if err != nil {
+		return &api.APIV1AdminMembersIDHistoryGetInternalServerError{Message: "Database error querying for candidate."}, nil
+	}
+	if !exists {
+		return &api.APIV1AdminMembersIDHistoryGetNotFound{Message: "Candidate not found."}, nil
+	}
+	scores, err := h.memberService.GetScoreHistory(params.ID)
+	if err != nil {
+		return &api.APIV1AdminMembersIDHistoryGetInternalServerError{Message: "Database error querying for scores."}, nil
+	}
+	result := api.APIV1AdminMembersIDHistoryGetOKApplicationJSON(lo.Map(scores, func(score models.Score, _ int) api.APIV1AdminMembersIDHistoryGetOKItem {
+		item := api.APIV1AdminMembersIDHistoryGetOKItem{
+			Challenge:        score.ChallengeType,
+			Valid:            score.IsValid,
+			ChallengeVersion: score.ChallengeVersion,
+			SubmittedAt:      score.CreatedAt,
+		}
+		if score.IsValid {
+			item.Score = api.NewOptInt(score.Score)
+		}
+		if score.LatencyP50Ms != nil && score.LatencyP95Ms != nil {
+			item.LatencyP50Ms = api.NewOptInt(int(*score.LatencyP50Ms))
+			item.LatencyP95Ms = api.NewOptInt(int(*score.LatencyP95Ms))
+		}
+		return item
+	}))
+	return &result, nil
+}
+
+// APIV1AdminMembersIDFrontendUsageGet implements api.Handler.
+func (h Handler) APIV1AdminMembersIDFrontendUsageGet(ctx context.Context, params api.APIV1AdminMembersIDFrontendUsageGetParams) (api.APIV1AdminMembersIDFrontendUsageGetRes, error) {
+	exists, err := h.memberService.CheckMemberExistsById(params.ID)
+	if err != nil {
+		return &api.APIV1AdminMembersIDFrontendUsageGetInternalServerError{Message: "Database error querying for candidate."}, nil
+	}
+	if !exists {
+		return &api.APIV1AdminMembersIDFrontendUsageGetNotFound{Message: "Candidate not found."}, nil
+	}
+	usage, err := h.memberService.GetFrontendUsageSummary(params.ID)
+	if err != nil {
+		return &api.APIV1AdminMembersIDFrontendUsageGetInternalServerError{Message: "Database error querying for frontend usage."}, nil
+	}
+	result := &api.APIV1AdminMembersIDFrontendUsageGetOK{Requests: int(usage.Requests)}
+	if usage.FirstRequestAt != nil {
+		result.FirstRequestAt = api.NewOptDateTime(*usage.FirstRequestAt)
+	}
+	if usage.LastRequestAt != nil {
+		result.LastRequestAt = api.NewOptDateTime(*usage.LastRequestAt)
+	}
+	return result, nil
+}
+
+// APIV1AdminMembersIDAttemptsChallengeDelete implements api.Handler.
+func (h Handler) APIV1AdminMembersIDAttemptsChallengeDelete(ctx context.Context, params api.APIV1AdminMembersIDAttemptsChallengeDeleteParams) (api.APIV1AdminMembersIDAttemptsChallengeDeleteRes, error) {
+	if _, ok := h.challenges.Get(params.Challenge); !ok {
+		return &api.APIV1AdminMembersIDAttemptsChallengeDeleteNotFound{Message: "Challenge not found."}, nil
+	}
+	exists, err := h.memberService.CheckMemberExistsById(params.ID)
+	if err != nil {
+		return &api.APIV1AdminMembersIDAttemptsChallengeDeleteInternalServerError{Message: "Database error querying for candidate."}, nil
+	}
+	if !exists {
+		return &api.APIV1AdminMembersIDAttemptsChallengeDeleteNotFound{Message: "Candidate not found."}, nil
+	}
+	if err := h.memberService.ResetAttempts(params.ID, params.Challenge); err != nil {
+		return &api.APIV1AdminMembersIDAttemptsChallengeDeleteInternalServerError{Message: "Database error resetting attempts."}, nil
+	}
+	h.logger.Info("Reset attempts", "member", params.ID, "challenge", params.Challenge)
+	return &api.APIV1AdminMembersIDAttemptsChallengeDeleteNoContent{}, nil
+}
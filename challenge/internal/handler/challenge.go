@@ -2,10 +2,13 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	api "generate_technical_challenge_2025/internal/api"
 	"generate_technical_challenge_2025/internal/database/models"
 	"generate_technical_challenge_2025/internal/services"
 	"generate_technical_challenge_2025/internal/utils"
+	"net/http"
 	"net/url"
 	"sort"
 
@@ -15,27 +18,59 @@ import (
 
 var globalRateLimiter = utils.NewRateLimiter()
 
+// APIV1ChallengesGet implements api.Handler.
+func (h Handler) APIV1ChallengesGet(ctx context.Context) ([]api.APIV1ChallengesGetOKItem, error) {
+	return lo.Map(h.challenges.All(), func(challenge services.Challenge, _ int) api.APIV1ChallengesGetOKItem {
+		return api.APIV1ChallengesGetOKItem{Type: challenge.Type(), Title: challenge.Title()}
+	}), nil
+}
+
+// APIV1LeaderboardGet implements api.Handler.
+func (h Handler) APIV1LeaderboardGet(ctx context.Context, params api.APIV1LeaderboardGetParams) (api.APIV1LeaderboardGetRes, error) {
+	leaderboard, err := h.leaderboardService.GetLeaderboard(params.Challenge, params.Cohort.Value)
+	switch {
+	case errors.Is(err, services.ErrUnknownChallengeType):
+		return &api.APIV1LeaderboardGetBadRequest{Message: "Unknown challenge, see /api/v1/challenges."}, nil
+	case errors.Is(err, services.ErrCohortNotFound):
+		return &api.APIV1LeaderboardGetNotFound{Message: "Cohort not found."}, nil
+	case err != nil:
+		return &api.APIV1LeaderboardGetInternalServerError{Message: "Database error querying for scores."}, nil
+	}
+	return &api.APIV1LeaderboardGetOK{
+		Challenge:        leaderboard.ChallengeType,
+		ChallengeVersion: leaderboard.ChallengeVersion,
+		GeneratedAt:      leaderboard.GeneratedAt,
+		Entries: lo.Map(leaderboard.Entries, func(entry services.LeaderboardEntry, _ int) api.APIV1LeaderboardGetOKEntriesItem {
+			return api.APIV1LeaderboardGetOKEntriesItem{
+				Rank:     entry.Rank,
+				Handle:   entry.Handle,
+				Score:    entry.Score,
+				Attempts: entry.Attempts,
+				BestAt:   entry.BestAt,
+			}
+		}),
+	}, nil
+}
+
 // APIV1ChallengeBackendIDAliensGet implements api.Handler.
 func (h Handler) APIV1ChallengeBackendIDAliensGet(ctx context.Context, params api.APIV1ChallengeBackendIDAliensGetParams) (api.APIV1ChallengeBackendIDAliensGetRes, error) {
-	exists, err := h.memberService.CheckMemberExistsById(params.ID)
+	member, exists, err := h.memberService.GetMemberWithCohort(params.ID)
 	if err != nil {
 		return &api.APIV1ChallengeBackendIDAliensGetInternalServerError{Message: "Database error finding member Id."}, nil
 	}
 	if !exists {
 		return &api.APIV1ChallengeBackendIDAliensGetNotFound{Message: "Unable to find member id."}, nil
 	}
-	waves := h.challengeService.GenerateUniqueAlienChallenge(params.ID)
-	// Sort the keys and index through so that the users get the same order.
-	keys := lo.Keys(waves)
-	sort.Slice(keys, func(i, j int) bool {
-		return keys[i].String() < keys[j].String()
-	})
-	states := lo.Map(keys, func(key uuid.UUID, _ int) api.APIV1ChallengeBackendIDAliensGetOKItem {
-		val := waves[key]
-		alienMap := lo.Map(val.SurveyRemainingAlienInvasion(), func(alien services.Alien, _ int) api.APIV1ChallengeBackendIDAliensGetOKItemAliensItem {
+	if err := services.CheckChallengeAccess(member.Cohort, models.ALGORITHM_CHALLENGE_TYPE); err != nil {
+		return &api.APIV1ChallengeBackendIDAliensGetForbidden{Message: cohortAccessMessage(err)}, nil
+	}
+	challenge, _ := h.challenges.Get(models.ALGORITHM_CHALLENGE_TYPE)
+	waves := challenge.PublicView(params.ID, member.ChallengeVersion).([]services.AlienWaveView)
+	states := lo.Map(waves, func(wave services.AlienWaveView, _ int) api.APIV1ChallengeBackendIDAliensGetOKItem {
+		alienMap := lo.Map(wave.Aliens, func(alien services.Alien, _ int) api.APIV1ChallengeBackendIDAliensGetOKItemAliensItem {
 			return api.APIV1ChallengeBackendIDAliensGetOKItemAliensItem{Hp: alien.Hp, Atk: alien.Atk}
 		})
-		return api.APIV1ChallengeBackendIDAliensGetOKItem{ChallengeID: key, Aliens: alienMap, Hp: val.GetHpLeft()}
+		return api.APIV1ChallengeBackendIDAliensGetOKItem{ChallengeID: wave.ChallengeID, Aliens: alienMap, Hp: wave.Hp}
 	})
 	result := api.APIV1ChallengeBackendIDAliensGetOKApplicationJSON(states)
 	return &result, nil
@@ -43,20 +78,13 @@ func (h Handler) APIV1ChallengeBackendIDAliensGet(ctx context.Context, params ap
 
 // APIV1ChallengeBackendIDAliensSubmitPost implements api.Handler.
 func (h Handler) APIV1ChallengeBackendIDAliensSubmitPost(ctx context.Context, req []api.APIV1ChallengeBackendIDAliensSubmitPostReqItem, params api.APIV1ChallengeBackendIDAliensSubmitPostParams) (api.APIV1ChallengeBackendIDAliensSubmitPostRes, error) {
-	exists, err := h.memberService.CheckMemberExistsById(params.ID)
+	member, exists, err := h.memberService.GetMemberWithCohort(params.ID)
 	if err != nil {
 		return &api.APIV1ChallengeBackendIDAliensSubmitPostInternalServerError{Message: "Database error finding member Id."}, nil
 	}
 	if !exists {
 		return &api.APIV1ChallengeBackendIDAliensSubmitPostNotFound{Message: "Unable to find member id."}, nil
 	}
-
-	uuidStr := params.ID.String()
-	if !globalRateLimiter.Allow(uuidStr) {
-		return &api.APIV1ChallengeBackendIDAliensSubmitPostTooManyRequests{
-			Message: "Rate limit exceeded: 10 requests per minute per challenge ID",
-		}, nil
-	}
 	mapVals := lo.SliceToMap(req, func(userSubmission api.APIV1ChallengeBackendIDAliensSubmitPostReqItem) (uuid.UUID, services.UserChallengeSubmission) {
 		var commands []string
 		for _, cmd := range userSubmission.State.Commands {
@@ -64,41 +92,207 @@ func (h Handler) APIV1ChallengeBackendIDAliensSubmitPost(ctx context.Context, re
 		}
 		return userSubmission.ChallengeID.Value, services.UserChallengeSubmission{Hp: userSubmission.State.RemainingHP, Commands: commands, AliensLeft: userSubmission.State.RemainingAliens}
 	})
-	ans := h.challengeService.ScoreMemberSubmission(params.ID, mapVals)
-	response := &api.APIV1ChallengeBackendIDAliensSubmitPostOK{Valid: ans.Valid, Message: ans.Message}
+	grade, rejection := h.submitChallenge(ctx, member, models.ALGORITHM_CHALLENGE_TYPE, mapVals)
+	if rejection != nil {
+		switch rejection.status {
+		case http.StatusForbidden:
+			return &api.APIV1ChallengeBackendIDAliensSubmitPostForbidden{Message: rejection.message}, nil
+		case http.StatusTooManyRequests:
+			return &api.APIV1ChallengeBackendIDAliensSubmitPostTooManyRequests{Message: rejection.message}, nil
+		}
+		return &api.APIV1ChallengeBackendIDAliensSubmitPostInternalServerError{Message: rejection.message}, rejection.err
+	}
+	ans := grade.Details.(services.OracleAnswer)
+	response := &api.APIV1ChallengeBackendIDAliensSubmitPostOK{Valid: ans.Valid, Message: ans.Message, Waves: lo.Map(ans.Waves, waveResultToAPI)}
 	if ans.Valid {
 		response.Score = api.OptInt{Value: ans.Score, Set: true}
-		valid := true
-		score := models.CreateScore(params.ID, models.ALGORITHM_CHALLENGE_TYPE, ans.Score, valid)
-		_, err := h.memberService.CreateScore(score)
-		if err != nil {
-			return &api.APIV1ChallengeBackendIDAliensSubmitPostInternalServerError{Message: "Database error when saving a score."}, err
-		}
-	} else {
-		valid := false
-		score := models.CreateScore(params.ID, models.ALGORITHM_CHALLENGE_TYPE, models.INVALID_SCORE, valid)
-		_, err := h.memberService.CreateScore(score)
-		if err != nil {
-			return &api.APIV1ChallengeBackendIDAliensSubmitPostInternalServerError{Message: "Database error when saving a score."}, err
+	}
+	return response, nil
+}
+
+// APIV1ChallengeBackendIDAliensSolutionGet implements api.Handler.
+func (h Handler) APIV1ChallengeBackendIDAliensSolutionGet(ctx context.Context, params api.APIV1ChallengeBackendIDAliensSolutionGetParams) (api.APIV1ChallengeBackendIDAliensSolutionGetRes, error) {
+	member, exists, err := h.memberService.GetMemberWithCohort(params.ID)
+	if err != nil {
+		return &api.APIV1ChallengeBackendIDAliensSolutionGetInternalServerError{Message: "Database error finding member Id."}, nil
+	}
+	if !exists {
+		return &api.APIV1ChallengeBackendIDAliensSolutionGetNotFound{Message: "Unable to find member id."}, nil
+	}
+	// Unlike the other endpoints, solutions are only available once the cohort has closed.
+	if !member.Cohort.HasChallenge(models.ALGORITHM_CHALLENGE_TYPE) {
+		return &api.APIV1ChallengeBackendIDAliensSolutionGetForbidden{Message: cohortAccessMessage(services.ErrChallengeNotInCohort)}, nil
+	}
+	solutions, err := h.challengeService.RevealAlienChallengeSolutions(params.ID, member.ChallengeVersion, member.Cohort)
+	if errors.Is(err, services.ErrCohortStillOpen) {
+		return &api.APIV1ChallengeBackendIDAliensSolutionGetForbidden{Message: "Solutions are only available once your cohort has closed."}, nil
+	}
+	if err != nil {
+		return &api.APIV1ChallengeBackendIDAliensSolutionGetInternalServerError{Message: "Error solving challenge."}, err
+	}
+	// Sort the keys and index through so that the users get the same order.
+	keys := lo.Keys(solutions)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	states := lo.Map(keys, func(key uuid.UUID, _ int) api.APIV1ChallengeBackendIDAliensSolutionGetOKItem {
+		val := solutions[key]
+		commands := lo.Map(val.GetCommandsUsed(), func(command string, _ int) api.APIV1ChallengeBackendIDAliensSolutionGetOKItemStateCommandsItem {
+			return api.APIV1ChallengeBackendIDAliensSolutionGetOKItemStateCommandsItem(command)
+		})
+		return api.APIV1ChallengeBackendIDAliensSolutionGetOKItem{
+			ChallengeID: api.NewOptUUID(key),
+			State: api.APIV1ChallengeBackendIDAliensSolutionGetOKItemState{
+				RemainingHP:     val.GetHpLeft(),
+				RemainingAliens: val.GetAliensLeft(),
+				Commands:        commands,
+			},
 		}
+	})
+	result := api.APIV1ChallengeBackendIDAliensSolutionGetOKApplicationJSON(states)
+	return &result, nil
+}
+
+// APIV1ChallengeBackendIDAliensChallengeIdReplayGet implements api.Handler.
+func (h Handler) APIV1ChallengeBackendIDAliensChallengeIdReplayGet(ctx context.Context, params api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetParams) (api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetRes, error) {
+	member, exists, err := h.memberService.GetMemberWithCohort(params.ID)
+	if err != nil {
+		return &api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetInternalServerError{Message: "Database error finding member Id."}, nil
+	}
+	if !exists {
+		return &api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetNotFound{Message: "Unable to find member id."}, nil
+	}
+	if err := services.CheckChallengeAccess(member.Cohort, models.ALGORITHM_CHALLENGE_TYPE); err != nil {
+		return &api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetForbidden{Message: cohortAccessMessage(err)}, nil
+	}
+	state, ok := h.challengeService.GenerateUniqueAlienChallenge(params.ID, member.ChallengeVersion)[params.ChallengeId]
+	if !ok {
+		return &api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetNotFound{Message: "Unable to find challenge id."}, nil
+	}
+	commands := lo.Map(params.Commands, func(command api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetCommandsItem, _ int) string {
+		return string(command)
+	})
+	steps, completed := services.ReplayCommands(state, commands)
+	response := &api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetOK{
+		ChallengeID: params.ChallengeId,
+		Steps: lo.Map(steps, func(step services.ReplayStep, _ int) api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetOKStepsItem {
+			item := api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetOKStepsItem{
+				HitAliens:    step.HitAliens,
+				KilledAliens: step.KilledAliens,
+				Aliens: lo.Map(step.State.SurveyRemainingAlienInvasion(), func(alien services.Alien, _ int) api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetOKStepsItemAliensItem {
+					return api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetOKStepsItemAliensItem{Hp: alien.Hp, Atk: alien.Atk}
+				}),
+				Hp: step.State.GetHpLeft(),
+			}
+			if step.Command != "" {
+				item.Command = api.NewOptAPIV1ChallengeBackendIDAliensChallengeIdReplayGetOKStepsItemCommand(
+					api.APIV1ChallengeBackendIDAliensChallengeIdReplayGetOKStepsItemCommand(step.Command))
+			}
+			return item
+		}),
+	}
+	if !completed {
+		response.Message = api.NewOptString("Invasion ended before all commands could be executed, the remaining commands were ignored.")
 	}
 	return response, nil
 }
 
+// Why a submission was turned away by submitChallenge.
+type submitRejection struct {
+	status  int    // HTTP status to respond with.
+	message string // Shown to the member.
+	err     error  // Set when grading failed, so that it is reported.
+}
+
+// Grades the member's submission through the challenge's services.Challenge.Grade, once they are allowed to submit
+// it and an attempt is reserved for it. The attempt is refunded if the submission could not be graded, since it was
+// never recorded.
+func (h Handler) submitChallenge(ctx context.Context, member *models.Member, challengeType string, submission any) (services.ChallengeGrade, *submitRejection) {
+	challenge, ok := h.challenges.Get(challengeType)
+	if !ok {
+		return services.ChallengeGrade{}, &submitRejection{http.StatusInternalServerError, "Unknown challenge.", services.ErrUnknownChallengeType}
+	}
+	if err := services.CheckChallengeAccess(member.Cohort, challengeType); err != nil {
+		return services.ChallengeGrade{}, &submitRejection{http.StatusForbidden, cohortAccessMessage(err), nil}
+	}
+	if member.PendingVerification {
+		return services.ChallengeGrade{}, &submitRejection{http.StatusForbidden, PENDING_VERIFICATION_MESSAGE, nil}
+	}
+	if !globalRateLimiter.Allow(member.ID.String()) {
+		return services.ChallengeGrade{}, &submitRejection{http.StatusTooManyRequests, "Rate limit exceeded: 10 requests per minute per challenge ID", nil}
+	}
+	if quota, err := h.memberService.ReserveAttempt(member.ID, challengeType); err != nil {
+		if errors.Is(err, services.ErrAttemptLimitReached) {
+			return services.ChallengeGrade{}, &submitRejection{http.StatusForbidden, attemptLimitMessage(quota), nil}
+		}
+		return services.ChallengeGrade{}, &submitRejection{http.StatusInternalServerError, "Database error when counting attempts.", nil}
+	}
+
+	grade, err := challenge.Grade(ctx, member.ID, member.ChallengeVersion, submission)
+	if err == nil {
+		return grade, nil
+	}
+	if err := h.memberService.RefundAttempt(member.ID, challengeType); err != nil {
+		h.logger.Error("Failed to refund attempt.", "member", member.ID, "challenge", challengeType, "error", err)
+	}
+	if errors.Is(err, services.ErrNgrokQueueFull) {
+		return services.ChallengeGrade{}, &submitRejection{http.StatusTooManyRequests, services.NGROK_JOB_QUEUE_FULL_MESSAGE, nil}
+	}
+	return services.ChallengeGrade{}, &submitRejection{http.StatusInternalServerError, "Database error when saving a submission.", err}
+}
+
+// Message shown to members when services.CheckChallengeAccess or services.CheckCohortOpen fails.
+func cohortAccessMessage(err error) string {
+	if errors.Is(err, services.ErrChallengeNotInCohort) {
+		return "This challenge is not part of your cohort."
+	}
+	return "Your cohort is not open right now."
+}
+
+// Message shown to members that submit before verifying their email.
+const PENDING_VERIFICATION_MESSAGE = "Verify your email with the code we sent before submitting."
+
+// Message shown to members when services.ErrAttemptLimitReached is returned.
+func attemptLimitMessage(quota services.AttemptQuota) string {
+	return fmt.Sprintf("Attempt limit reached: you have used all %d submissions for this challenge.", quota.Limit)
+}
+
+func waveResultToAPI(wave services.WaveResult, _ int) api.APIV1ChallengeBackendIDAliensSubmitPostOKWavesItem {
+	item := api.APIV1ChallengeBackendIDAliensSubmitPostOKWavesItem{ChallengeID: wave.ChallengeID, Valid: wave.Valid}
+	if wave.FinalState != nil {
+		item.State = api.NewOptAPIV1ChallengeBackendIDAliensSubmitPostOKWavesItemState(api.APIV1ChallengeBackendIDAliensSubmitPostOKWavesItemState{
+			RemainingHP:     wave.FinalState.GetHpLeft(),
+			RemainingAliens: wave.FinalState.GetAliensLeft(),
+			CommandsUsed:    wave.FinalState.GetNumberOfCommandsUsed(),
+		})
+	}
+	if wave.Valid {
+		item.HpScore = api.NewOptInt(wave.HpScore)
+		item.AlienScore = api.NewOptInt(wave.AlienScore)
+		item.CommandScore = api.NewOptInt(wave.CommandScore)
+	} else {
+		item.Reason = api.NewOptString(wave.Reason)
+	}
+	return item
+}
+
 // APIV1ChallengeFrontendIDAliensGet implements api.Handler.
 // Note:
 // generates a random number of aliens between LOWER_DETAILED_ALIEN_AMOUNT and UPPER_DETAILED_ALIEN_AMOUNT, and then
 // limits/offsets it.
 func (h Handler) APIV1ChallengeFrontendIDAliensGet(ctx context.Context, params api.APIV1ChallengeFrontendIDAliensGetParams) (api.APIV1ChallengeFrontendIDAliensGetRes, error) {
-	exists, err := h.memberService.CheckMemberExistsById(params.ID)
+	member, exists, err := h.memberService.GetMemberWithCohort(params.ID)
 	if err != nil {
 		return &api.APIV1ChallengeFrontendIDAliensGetInternalServerError{Message: "Database error finding member Id."}, nil
 	}
 	if !exists {
 		return &api.APIV1ChallengeFrontendIDAliensGetNotFound{Message: "Unable to find member id."}, nil
 	}
+	if err := services.CheckCohortOpen(member.Cohort); err != nil {
+		return &api.APIV1ChallengeFrontendIDAliensGetForbidden{Message: cohortAccessMessage(err)}, nil
+	}
 
-	detailedAliens := h.challengeService.GenerateUniqueFrontendChallenge(params.ID)
+	detailedAliens := h.challengeService.GenerateUniqueFrontendChallenge(params.ID, member.ChallengeVersion)
 
 	start := 0
 	if params.Offset.Set {
@@ -143,68 +337,91 @@ func (h Handler) APIV1ChallengeFrontendIDAliensGet(ctx context.Context, params a
 
 // APIV1ChallengeBackendIDNgrokSubmitPost implements api.Handler.
 func (h Handler) APIV1ChallengeBackendIDNgrokSubmitPost(ctx context.Context, req api.OptAPIV1ChallengeBackendIDNgrokSubmitPostReq, params api.APIV1ChallengeBackendIDNgrokSubmitPostParams) (api.APIV1ChallengeBackendIDNgrokSubmitPostRes, error) {
-	exists, err := h.memberService.CheckMemberExistsById(params.ID)
+	member, exists, err := h.memberService.GetMemberWithCohort(params.ID)
 	if err != nil {
 		return &api.APIV1ChallengeBackendIDNgrokSubmitPostInternalServerError{Message: "Database error finding member Id."}, nil
 	}
 	if !exists {
 		return &api.APIV1ChallengeBackendIDNgrokSubmitPostBadRequest{Message: "Unable to find member id."}, nil
 	}
+	grade, rejection := h.submitChallenge(ctx, member, models.NGROK_CHALLENGE_TYPE, req.Value.URL.Value)
+	if rejection != nil {
+		switch rejection.status {
+		case http.StatusForbidden:
+			return &api.APIV1ChallengeBackendIDNgrokSubmitPostForbidden{Message: rejection.message}, nil
+		case http.StatusTooManyRequests:
+			return &api.APIV1ChallengeBackendIDNgrokSubmitPostTooManyRequests{Message: rejection.message}, nil
+		}
+		return &api.APIV1ChallengeBackendIDNgrokSubmitPostInternalServerError{Message: rejection.message}, rejection.err
+	}
+	job := grade.Details.(*models.NgrokJob)
+	return &api.APIV1ChallengeBackendIDNgrokSubmitPostAccepted{
+		JobId:  job.ID,
+		Status: api.APIV1ChallengeBackendIDNgrokSubmitPostAcceptedStatus(job.Status),
+	}, nil
+}
 
-	uuidStr := params.ID.String()
-	if !globalRateLimiter.Allow(uuidStr) {
-		return &api.APIV1ChallengeBackendIDNgrokSubmitPostTooManyRequests{
-			Message: "Rate limit exceeded: 10 requests per minute per challenge ID",
-		}, nil
+// APIV1ChallengeBackendIDNgrokJobsJobIdGet implements api.Handler.
+func (h Handler) APIV1ChallengeBackendIDNgrokJobsJobIdGet(ctx context.Context, params api.APIV1ChallengeBackendIDNgrokJobsJobIdGetParams) (api.APIV1ChallengeBackendIDNgrokJobsJobIdGetRes, error) {
+	member, exists, err := h.memberService.GetMemberWithCohort(params.ID)
+	if err != nil {
+		return &api.APIV1ChallengeBackendIDNgrokJobsJobIdGetInternalServerError{Message: "Database error finding member Id."}, nil
+	}
+	if !exists {
+		return &api.APIV1ChallengeBackendIDNgrokJobsJobIdGetNotFound{Message: "Unable to find member id."}, nil
+	}
+	if err := services.CheckChallengeAccess(member.Cohort, models.NGROK_CHALLENGE_TYPE); err != nil {
+		return &api.APIV1ChallengeBackendIDNgrokJobsJobIdGetForbidden{Message: cohortAccessMessage(err)}, nil
 	}
 
-	ok, err := h.challengeService.HealthCheck(ctx, req.Value.URL.Value)
-	if err != nil || !ok {
-		gradeResult := services.NgrokChallengeScore{
-			Valid:  false,
-			Reason: "Health check failed--server unreachable",
-		}
+	job, exists, err := h.challengeService.GetNgrokJob(params.ID, params.JobId)
+	if err != nil {
+		return &api.APIV1ChallengeBackendIDNgrokJobsJobIdGetInternalServerError{Message: "Database error finding grading job."}, nil
+	}
+	if !exists {
+		return &api.APIV1ChallengeBackendIDNgrokJobsJobIdGetNotFound{Message: "Unable to find grading job for member id."}, nil
+	}
 
-		// Grading failed:
-		result := api.APIV1ChallengeBackendIDNgrokSubmitPostOK{
-			Valid:   false,
-			Message: gradeResult.Reason,
-		}
-		score := models.CreateScore(params.ID, models.NGROK_CHALLENGE_TYPE, models.INVALID_SCORE, gradeResult.Valid)
-		_, err := h.memberService.CreateScore(score)
-		if err != nil {
-			return &api.APIV1ChallengeBackendIDNgrokSubmitPostInternalServerError{Message: "Database error when saving a score."}, err
+	result := api.APIV1ChallengeBackendIDNgrokJobsJobIdGetOK{
+		JobId:   job.ID,
+		Status:  api.APIV1ChallengeBackendIDNgrokJobsJobIdGetOKStatus(job.Status),
+		Message: job.Message,
+		Checks:  lo.Map(job.Checks, ngrokJobCheckToAPI),
+	}
+	if job.Score != nil {
+		result.Valid = api.NewOptBool(job.Score.IsValid)
+		if job.Score.IsValid {
+			result.Score = api.NewOptInt(job.Score.Score)
+			result.LatencyP50Ms = api.NewOptInt(int(job.LatencyP50Ms))
+			result.LatencyP95Ms = api.NewOptInt(int(job.LatencyP95Ms))
+			result.LatencyPoints = api.NewOptInt(job.LatencyPoints)
 		}
-		return &result, nil
 	}
+	return &result, nil
+}
 
-	generatedRequests := h.challengeService.GenerateUniqueNgrokChallenge(params.ID)
-	gradeResult := h.challengeService.GradeNgrokServer(ctx, req.Value.URL.Value, generatedRequests)
-
-	if gradeResult.Valid {
-		// Successful grading:
-		result := api.APIV1ChallengeBackendIDNgrokSubmitPostOK{
-			Valid:   true,
-			Score:   api.NewOptInt(gradeResult.Score),
-			Message: "Submission has been been successfully scored.",
-		}
-		score := models.CreateScore(params.ID, models.NGROK_CHALLENGE_TYPE, gradeResult.Score, gradeResult.Valid)
-		_, err := h.memberService.CreateScore(score)
-		if err != nil {
-			return &api.APIV1ChallengeBackendIDNgrokSubmitPostInternalServerError{Message: "Database error when saving a score."}, err
-		}
-		return &result, nil
-	} else {
-		// Grading failed:
-		result := api.APIV1ChallengeBackendIDNgrokSubmitPostOK{
-			Valid:   false,
-			Message: gradeResult.Reason,
-		}
-		score := models.CreateScore(params.ID, models.NGROK_CHALLENGE_TYPE, models.INVALID_SCORE, gradeResult.Valid)
-		_, err := h.memberService.CreateScore(score)
-		if err != nil {
-			return &api.APIV1ChallengeBackendIDNgrokSubmitPostInternalServerError{Message: "Database error when saving a score."}, err
-		}
-		return &result, nil
+func ngrokJobCheckToAPI(check models.NgrokJobCheck, _ int) api.APIV1ChallengeBackendIDNgrokJobsJobIdGetOKChecksItem {
+	item := api.APIV1ChallengeBackendIDNgrokJobsJobIdGetOKChecksItem{
+		Name:           check.Name,
+		Method:         check.Method,
+		Path:           check.Path,
+		PointsEarned:   check.PointsEarned,
+		PointsPossible: check.PointsPossible,
+		LatencyMs:      int(check.LatencyMs),
+	}
+	if check.StatusCode != 0 {
+		item.StatusCode = api.NewOptInt(check.StatusCode)
+	}
+	if check.Error != "" {
+		item.Error = api.NewOptString(check.Error)
+	}
+	if check.Compared {
+		item.ExpectedCount = api.NewOptInt(check.ExpectedCount)
+		item.ActualCount = api.NewOptInt(check.ActualCount)
+		item.Distance = api.NewOptInt(check.Distance)
+		item.MissingIDs = check.MissingIDs
+		item.UnexpectedIDs = check.UnexpectedIDs
+		item.MismatchedIDs = check.MismatchedIDs
 	}
+	return item
 }
@@ -14,9 +14,13 @@ import (
 
 // Handles incoming API requests
 type Handler struct {
-	memberService    services.MemberService
-	challengeService services.ChallengeService
-	logger           *slog.Logger // event logger
+	memberService       services.MemberService
+	challengeService    services.ChallengeService
+	cohortService       services.CohortService
+	leaderboardService  services.LeaderboardService
+	verificationService services.VerificationService
+	challenges          *services.ChallengeRegistry
+	logger              *slog.Logger // event logger
 }
 
 // ChallengeGet implements api.Handler.
@@ -46,10 +50,14 @@ func (h Handler) HealthcheckGet(ctx context.Context) (*api.HealthcheckGetOK, err
 }
 
 // Creates a new handler for all defined API endpoints
-func CreateHandler(logger *slog.Logger, memberService services.MemberService, challengeService services.ChallengeService) api.Handler {
+func CreateHandler(logger *slog.Logger, memberService services.MemberService, challengeService services.ChallengeService, cohortService services.CohortService, leaderboardService services.LeaderboardService, verificationService services.VerificationService, challenges *services.ChallengeRegistry) api.Handler {
 	return Handler{
 		memberService,
 		challengeService,
+		cohortService,
+		leaderboardService,
+		verificationService,
+		challenges,
 		logger,
 	}
 }
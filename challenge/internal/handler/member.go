@@ -3,9 +3,16 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	api "generate_technical_challenge_2025/internal/api"
 	models "generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
 	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 func validateNEUEmail(email string) bool {
@@ -17,22 +24,8 @@ func validateNUID(nuid string) bool {
 	return len(nuid) == 9
 }
 
-// APIV1MemberGet implements api.Handler.
-func (h Handler) APIV1MemberGet(ctx context.Context, params api.APIV1MemberGetParams) (api.APIV1MemberGetRes, error) {
-	if !validateNEUEmail(params.Email) {
-		return &api.APIV1MemberGetBadRequest{Message: "Not a valid northeastern email address"}, nil
-	}
-	if !validateNUID(params.Nuid) {
-		return &api.APIV1MemberGetBadRequest{Message: "Not a valid NUID"}, nil
-	}
-	id, err := h.memberService.GetMember(params.Email, params.Nuid)
-	if err != nil {
-		return &api.APIV1MemberGetNotFound{Message: "Could not find a northeastern email address or nuid associated."}, nil
-	}
-	return &api.APIV1MemberGetOK{ID: *id}, nil
-}
-
 // APIV1MemberRegisterPost implements api.Handler.
+// Members are created pending verification, they get their id once they enter the code emailed to them.
 func (h Handler) APIV1MemberRegisterPost(ctx context.Context, req api.OptAPIV1MemberRegisterPostReq) (api.APIV1MemberRegisterPostRes, error) {
 	email := req.Value.GetEmail()
 	nuid := req.Value.GetNuid()
@@ -43,18 +36,233 @@ func (h Handler) APIV1MemberRegisterPost(ctx context.Context, req api.OptAPIV1Me
 	if !validateNUID(nuid) {
 		return &api.APIV1MemberRegisterPostBadRequest{Message: "Not a valid NUID."}, nil
 	}
-	exists, err := h.memberService.CheckMemberExistsByEmailAndNuid(email, nuid)
+	cohort, err := h.cohortService.GetRegistrationCohort(req.Value.Cohort.Value)
+	switch {
+	case errors.Is(err, services.ErrCohortNotFound):
+		return &api.APIV1MemberRegisterPostNotFound{Message: "Cohort not found."}, nil
+	case errors.Is(err, services.ErrCohortRequired):
+		return &api.APIV1MemberRegisterPostBadRequest{Message: "Several cohorts are open, choose one with the cohort field."}, nil
+	case errors.Is(err, services.ErrCohortNotOpen):
+		return &api.APIV1MemberRegisterPostForbidden{Message: "Registration is closed."}, nil
+	case err != nil:
+		return &api.APIV1MemberRegisterPostInternalServerError{Message: "Database error querying for cohort."}, nil
+	}
+	member, exists, err := h.memberService.GetMember(email, nuid, cohort.ID)
 	if err != nil {
 		return &api.APIV1MemberRegisterPostInternalServerError{Message: "Database error querying for user."}, nil
 	}
-	if exists {
+	if exists && !member.PendingVerification {
 		return &api.APIV1MemberRegisterPostConflict{Message: "Member already exists."}, nil
 	}
-	// Deserialize input into internal model of users.
-	member := models.CreateMember(email, nuid)
-	id, err := h.memberService.CreateMember(member)
+	if !exists {
+		// Deserialize input into internal model of users.
+		member = models.CreateMember(email, nuid, cohort.ID, cohort.ChallengeVersion)
+		if _, err := h.memberService.CreateMember(member); err != nil {
+			return &api.APIV1MemberRegisterPostInternalServerError{Message: "Database error when creating a new user."}, err
+		}
+	}
+	// Registering again before verifying sends a new code, in case the first one never arrived.
+	err = h.verificationService.SendCode(ctx, member)
+	switch {
+	case errors.Is(err, services.ErrCodeRecentlySent):
+		return &api.APIV1MemberRegisterPostTooManyRequests{Message: codeRecentlySentMessage}, nil
+	case err != nil:
+		return &api.APIV1MemberRegisterPostInternalServerError{Message: "Error sending the verification code."}, nil
+	}
+	return &api.APIV1MemberRegisterPostAccepted{Message: codeSentMessage(email)}, nil
+}
+
+// APIV1MemberCodePost implements api.Handler.
+func (h Handler) APIV1MemberCodePost(ctx context.Context, req api.OptAPIV1MemberCodePostReq) (api.APIV1MemberCodePostRes, error) {
+	email := req.Value.GetEmail()
+	nuid := req.Value.GetNuid()
+	if !validateNEUEmail(email) {
+		return &api.APIV1MemberCodePostBadRequest{Message: "Not a valid northeastern email address."}, nil
+	}
+	if !validateNUID(nuid) {
+		return &api.APIV1MemberCodePostBadRequest{Message: "Not a valid NUID."}, nil
+	}
+	cohortID, exists, err := h.lookupCohortID(req.Value.Cohort)
+	if err != nil {
+		return &api.APIV1MemberCodePostInternalServerError{Message: "Database error querying for cohort."}, nil
+	}
+	if !exists {
+		return &api.APIV1MemberCodePostNotFound{Message: "Cohort not found."}, nil
+	}
+	member, exists, err := h.memberService.GetMember(email, nuid, cohortID)
+	if err != nil {
+		return &api.APIV1MemberCodePostInternalServerError{Message: "Database error querying for user."}, nil
+	}
+	if !exists {
+		return &api.APIV1MemberCodePostNotFound{Message: "Could not find a northeastern email address or nuid associated."}, nil
+	}
+	err = h.verificationService.SendCode(ctx, member)
+	switch {
+	case errors.Is(err, services.ErrCodeRecentlySent):
+		return &api.APIV1MemberCodePostTooManyRequests{Message: codeRecentlySentMessage}, nil
+	case err != nil:
+		return &api.APIV1MemberCodePostInternalServerError{Message: "Error sending the verification code."}, nil
+	}
+	return &api.APIV1MemberCodePostAccepted{Message: codeSentMessage(email)}, nil
+}
+
+// APIV1MemberVerifyPost implements api.Handler.
+// Members that already verified can verify again with a new code, to recover their id.
+func (h Handler) APIV1MemberVerifyPost(ctx context.Context, req api.OptAPIV1MemberVerifyPostReq) (api.APIV1MemberVerifyPostRes, error) {
+	email := req.Value.GetEmail()
+	nuid := req.Value.GetNuid()
+	if !validateNEUEmail(email) {
+		return &api.APIV1MemberVerifyPostBadRequest{Message: "Not a valid northeastern email address."}, nil
+	}
+	if !validateNUID(nuid) {
+		return &api.APIV1MemberVerifyPostBadRequest{Message: "Not a valid NUID."}, nil
+	}
+	cohortID, exists, err := h.lookupCohortID(req.Value.Cohort)
+	if err != nil {
+		return &api.APIV1MemberVerifyPostInternalServerError{Message: "Database error querying for cohort."}, nil
+	}
+	if !exists {
+		return &api.APIV1MemberVerifyPostNotFound{Message: "Cohort not found."}, nil
+	}
+	member, exists, err := h.memberService.GetMember(email, nuid, cohortID)
+	if err != nil {
+		return &api.APIV1MemberVerifyPostInternalServerError{Message: "Database error querying for user."}, nil
+	}
+	if !exists {
+		return &api.APIV1MemberVerifyPostNotFound{Message: "Could not find a northeastern email address or nuid associated."}, nil
+	}
+	err = h.verificationService.Verify(member.ID, req.Value.GetCode())
+	switch {
+	case errors.Is(err, services.ErrInvalidCode), errors.Is(err, services.ErrCodeExpired):
+		return &api.APIV1MemberVerifyPostBadRequest{Message: "Invalid or expired code."}, nil
+	case errors.Is(err, services.ErrTooManyCodeAttempts):
+		return &api.APIV1MemberVerifyPostTooManyRequests{Message: "Too many wrong codes, ask for a new one."}, nil
+	case err != nil:
+		return &api.APIV1MemberVerifyPostInternalServerError{Message: "Database error verifying code."}, nil
+	}
+	return &api.APIV1MemberVerifyPostOK{ID: member.ID}, nil
+}
+
+// Looks the cohort up by name, or returns uuid.Nil to look members up in every cohort when no name is given.
+func (h Handler) lookupCohortID(name api.OptString) (uuid.UUID, bool, error) {
+	if !name.Set {
+		return uuid.Nil, true, nil
+	}
+	cohort, exists, err := h.cohortService.GetCohortByName(name.Value)
+	if err != nil || !exists {
+		return uuid.Nil, exists, err
+	}
+	return cohort.ID, true, nil
+}
+
+const codeRecentlySentMessage = "A code was sent moments ago, check your inbox or wait a minute before asking for another."
+
+func codeSentMessage(email string) string {
+	return fmt.Sprintf("We emailed a verification code to %s, exchange it for your id with /api/v1/member/verify.", email)
+}
+
+// APIV1CohortsGet implements api.Handler.
+func (h Handler) APIV1CohortsGet(ctx context.Context) (api.APIV1CohortsGetRes, error) {
+	cohorts, err := h.cohortService.GetCohorts()
+	if err != nil {
+		return &api.APIV1CohortsGetInternalServerError{Message: "Database error querying for cohorts."}, nil
+	}
+	now := time.Now()
+	result := api.APIV1CohortsGetOKApplicationJSON(lo.Map(cohorts, func(cohort models.Cohort, _ int) api.APIV1CohortsGetOKItem {
+		item := api.APIV1CohortsGetOKItem{
+			Name:       cohort.Name,
+			OpensAt:    cohort.OpensAt,
+			Challenges: cohort.ChallengeTypes,
+			Open:       cohort.IsOpen(now),
+		}
+		if !cohort.ClosesAt.IsZero() {
+			item.ClosesAt = api.NewOptDateTime(cohort.ClosesAt)
+		}
+		return item
+	}))
+	return &result, nil
+}
+
+// APIV1MemberIDAttemptsGet implements api.Handler.
+func (h Handler) APIV1MemberIDAttemptsGet(ctx context.Context, params api.APIV1MemberIDAttemptsGetParams) (api.APIV1MemberIDAttemptsGetRes, error) {
+	member, exists, err := h.memberService.GetMemberWithCohort(params.ID)
+	if err != nil {
+		return &api.APIV1MemberIDAttemptsGetInternalServerError{Message: "Database error finding member Id."}, nil
+	}
+	if !exists {
+		return &api.APIV1MemberIDAttemptsGetNotFound{Message: "Unable to find member id."}, nil
+	}
+	challengeTypes := lo.Map(h.challenges.ForCohort(member.Cohort), func(challenge services.Challenge, _ int) string {
+		return challenge.Type()
+	})
+	quotas, err := h.memberService.GetAttemptQuotas(params.ID, challengeTypes)
+	if err != nil {
+		return &api.APIV1MemberIDAttemptsGetInternalServerError{Message: "Database error finding attempts."}, nil
+	}
+	result := api.APIV1MemberIDAttemptsGetOKApplicationJSON(lo.Map(quotas, func(quota services.AttemptQuota, _ int) api.APIV1MemberIDAttemptsGetOKItem {
+		item := api.APIV1MemberIDAttemptsGetOKItem{Challenge: quota.ChallengeType, Used: quota.Used}
+		if quota.Limit > 0 {
+			item.Limit = api.NewOptInt(quota.Limit)
+			item.Remaining = api.NewOptInt(quota.Remaining())
+		}
+		return item
+	}))
+	return &result, nil
+}
+
+// APIV1MemberIDStatusGet implements api.Handler.
+func (h Handler) APIV1MemberIDStatusGet(ctx context.Context, params api.APIV1MemberIDStatusGetParams) (api.APIV1MemberIDStatusGetRes, error) {
+	member, exists, err := h.memberService.GetMemberWithCohort(params.ID)
+	if err != nil {
+		return &api.APIV1MemberIDStatusGetInternalServerError{Message: "Database error finding member Id."}, nil
+	}
+	if !exists {
+		return &api.APIV1MemberIDStatusGetNotFound{Message: "Unable to find member id."}, nil
+	}
+	summaries, err := h.memberService.GetScoreSummaries(params.ID)
+	if err != nil {
+		return &api.APIV1MemberIDStatusGetInternalServerError{Message: "Database error finding scores."}, nil
+	}
+	usage, err := h.memberService.GetFrontendUsageSummary(params.ID)
+	if err != nil {
+		return &api.APIV1MemberIDStatusGetInternalServerError{Message: "Database error finding frontend usage."}, nil
+	}
+	challenges := h.challenges.ForCohort(member.Cohort)
+	challengeTypes := lo.Map(challenges, func(challenge services.Challenge, _ int) string {
+		return challenge.Type()
+	})
+	// Attempts are what counts against the limit, which includes submissions that are still being graded.
+	quotas, err := h.memberService.GetAttemptQuotas(params.ID, challengeTypes)
 	if err != nil {
-		return &api.APIV1MemberRegisterPostInternalServerError{Message: "Database error when creating a new user."}, err
+		return &api.APIV1MemberIDStatusGetInternalServerError{Message: "Database error finding attempts."}, nil
 	}
-	return &api.APIV1MemberRegisterPostCreated{ID: *id}, nil
+	return &api.APIV1MemberIDStatusGetOK{
+		Cohort:           member.Cohort.Name,
+		SubmissionTokens: globalRateLimiter.Tokens(params.ID.String()),
+		FrontendFetched:  usage.Requests > 0,
+		Challenges: lo.Map(challenges, func(challenge services.Challenge, idx int) api.APIV1MemberIDStatusGetOKChallengesItem {
+			quota := quotas[idx]
+			item := api.APIV1MemberIDStatusGetOKChallengesItem{
+				Challenge: challenge.Type(),
+				Title:     challenge.Title(),
+				MaxScore:  challenge.MaxScore(params.ID, member.ChallengeVersion),
+				Attempts:  quota.Used,
+			}
+			summary, submitted := lo.Find(summaries, func(summary services.ScoreSummary) bool {
+				return summary.ChallengeType == quota.ChallengeType
+			})
+			if !submitted {
+				return item
+			}
+			if summary.Best != nil {
+				item.BestScore = api.NewOptInt(summary.Best.Score)
+			}
+			item.LatestValid = api.NewOptBool(summary.Latest.IsValid)
+			if summary.Latest.IsValid {
+				item.LatestScore = api.NewOptInt(summary.Latest.Score)
+			}
+			item.LastSubmittedAt = api.NewOptDateTime(summary.Latest.CreatedAt)
+			return item
+		}),
+	}, nil
 }
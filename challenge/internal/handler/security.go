@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+	api "generate_technical_challenge_2025/internal/api"
+	"generate_technical_challenge_2025/internal/services"
+)
+
+// Authenticates interviewers and admins using the admin API.
+type SecurityHandler struct {
+	staff services.StaffAuthenticator
+}
+
+// HandleStaffToken implements api.SecurityHandler.
+// The roles of the token must cover the roles the operation requires.
+func (s SecurityHandler) HandleStaffToken(ctx context.Context, operationName api.OperationName, t api.StaffToken) (context.Context, error) {
+	role, ok := s.staff.Role(t.Token)
+	if !ok {
+		return ctx, services.ErrInvalidStaffToken
+	}
+	if !services.RoleAllows(role, t.Roles) {
+		return ctx, services.ErrRoleNotAllowed
+	}
+	return ctx, nil
+}
+
+func CreateSecurityHandler(staff services.StaffAuthenticator) api.SecurityHandler {
+	return SecurityHandler{staff: staff}
+}
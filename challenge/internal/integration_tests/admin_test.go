@@ -0,0 +1,91 @@
+package integrationtests
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func bearer(token string) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token}
+}
+
+func TestAdminAPIRequiresStaffToken(t *testing.T) {
+	testVerify := CLIENT.GET("/api/v1/admin/members?nuid=123456789")
+	testVerify.AssertStatusCode(401, t).AssertBody(map[string]any{"message": "Missing or invalid staff token."}, t)
+
+	testVerify = CLIENT.AddHeaders(bearer("not-a-token")).GET("/api/v1/admin/members?nuid=123456789")
+	testVerify.AssertStatusCode(401, t)
+}
+
+func TestAdminAPILooksUpCandidates(t *testing.T) {
+	memberID := registerMember(t, map[string]any{
+		"email": "lookup@northeastern.edu",
+		"nuid":  "987654321",
+	})
+
+	testVerify := CLIENT.AddHeaders(bearer(TEST_INTERVIEWER_TOKEN)).GET("/api/v1/admin/members")
+	testVerify.AssertStatusCode(400, t)
+
+	testVerify = CLIENT.AddHeaders(bearer(TEST_INTERVIEWER_TOKEN)).GET("/api/v1/admin/members?email=lookup@northeastern.edu")
+	var members []map[string]any
+	testVerify.AssertStatusCode(200, t).GetBody(&members, t)
+	assert.Len(t, members, 1)
+	assert.Equal(t, memberID, members[0]["id"])
+	assert.Equal(t, TEST_COHORT, members[0]["cohort"])
+
+	testVerify = CLIENT.AddBody([]map[string]any{}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
+	testVerify.AssertStatusCode(200, t)
+
+	testVerify = CLIENT.AddHeaders(bearer(TEST_INTERVIEWER_TOKEN)).GET("/api/v1/admin/members/" + memberID + "/scores")
+	var scores []map[string]any
+	testVerify.AssertStatusCode(200, t).GetBody(&scores, t)
+	assert.Len(t, scores, 1)
+	assert.Equal(t, models.ALGORITHM_CHALLENGE_TYPE, scores[0]["challenge"])
+	assert.Equal(t, 1.0, scores[0]["attempts"])
+	assert.NotContains(t, scores[0], "bestScore")
+
+	testVerify = CLIENT.AddHeaders(bearer(TEST_INTERVIEWER_TOKEN)).GET("/api/v1/admin/members/" + memberID + "/history")
+	var history []map[string]any
+	testVerify.AssertStatusCode(200, t).GetBody(&history, t)
+	assert.Len(t, history, 1)
+	assert.False(t, history[0]["valid"].(bool))
+
+	testVerify = CLIENT.AddHeaders(bearer(TEST_INTERVIEWER_TOKEN)).GET("/api/v1/admin/members/" + memberID + "/frontend-usage")
+	testVerify.AssertStatusCode(200, t).AssertBody(map[string]any{"requests": 0.0}, t)
+
+	testVerify = CLIENT.AddHeaders(bearer(TEST_INTERVIEWER_TOKEN)).GET("/api/v1/admin/members/00000000-0000-0000-0000-000000000000/scores")
+	testVerify.AssertStatusCode(404, t)
+}
+
+func TestOnlyAdminsResetAttempts(t *testing.T) {
+	memberID := registerMember(t, map[string]any{
+		"email": "reset@northeastern.edu",
+		"nuid":  "123456789",
+	})
+
+	for range TEST_ALGORITHM_MAX_ATTEMPTS {
+		testVerify := CLIENT.AddBody([]map[string]any{}).AddHeaders(map[string]string{
+			"Content-Type": "application/json",
+		}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
+		testVerify.AssertStatusCode(200, t)
+	}
+
+	endpoint := "/api/v1/admin/members/" + memberID + "/attempts/" + models.ALGORITHM_CHALLENGE_TYPE
+	testVerify := CLIENT.AddHeaders(bearer(TEST_INTERVIEWER_TOKEN)).DELETE(endpoint)
+	testVerify.AssertStatusCode(403, t).AssertBody(map[string]any{"message": "Your role is not allowed to do this."}, t)
+
+	testVerify = CLIENT.AddHeaders(bearer(TEST_ADMIN_TOKEN)).DELETE("/api/v1/admin/members/" + memberID + "/attempts/unknown")
+	testVerify.AssertStatusCode(404, t)
+
+	testVerify = CLIENT.AddHeaders(bearer(TEST_ADMIN_TOKEN)).DELETE(endpoint)
+	testVerify.AssertStatusCode(204, t)
+
+	testVerify = CLIENT.AddBody([]map[string]any{}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
+	testVerify.AssertStatusCode(200, t)
+}
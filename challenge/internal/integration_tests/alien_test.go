@@ -18,17 +18,11 @@ const (
 // A Collection of brute force tests to ensure reliablilty
 func TestBackendAlienChallengeFullIntegration(t *testing.T) {
 	// User registers with their NUID and Northeastern Email
-	client := CLIENT.AddBody(map[string]any{
+	memberID := registerMember(t, map[string]any{
 		"email": NORTHEASTERN_TEST_EMAIL,
 		"nuid":  NORTHEASTERN_TEST_NUID,
-	}).AddHeaders(map[string]string{
-		"Content-Type": "application/json",
 	})
-	testVerify := client.POST("/api/v1/member/register")
-	testVerify.AssertStatusCode(201, t)
-	var res map[string]string
-	testVerify.GetBody(&res, t)
-	testVerify = client.GET("/api/v1/challenge/backend/" + res["id"] + "/aliens")
+	testVerify := CLIENT.GET("/api/v1/challenge/backend/" + memberID + "/aliens")
 	testVerify.AssertStatusCode(200, t)
 	// Deserialize alien invasion data
 	type AlienData struct {
@@ -53,7 +47,7 @@ func TestBackendAlienChallengeFullIntegration(t *testing.T) {
 	})
 	// CASE 1: PERFECT USER SUBMISSION
 	perfectDeserializedInvasionStates := lo.MapEntries(deserializedInvasionStates, func(challengeIDs uuid.UUID, initialState services.InvasionState) (uuid.UUID, services.InvasionState) {
-		perfectSolution := services.OracleSolution(initialState)
+		perfectSolution := services.MemoizedOracleSolution(initialState)
 		return challengeIDs, perfectSolution
 	})
 	// serialize into response expected by server
@@ -69,32 +63,99 @@ func TestBackendAlienChallengeFullIntegration(t *testing.T) {
 	})
 	testVerify = CLIENT.AddBody(serializedAnswers).AddHeaders(map[string]string{
 		"Content-Type": "application/json",
-	}).POST("/api/v1/challenge/backend/" + res["id"] + "/aliens/submit")
+	}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
 	testVerify.AssertStatusCode(200, t)
 	response := map[string]any{}
 	testVerify.GetBody(&response, t)
 	assert.True(t, response["valid"].(bool))
 	assert.Equal(t, 0.0, response["score"].(float64))
+	// Every wave should be reported back as valid with no points lost.
+	waves := response["waves"].([]any)
+	assert.Len(t, waves, services.NUM_WAVES)
+	for _, wave := range waves {
+		waveResult := wave.(map[string]any)
+		assert.True(t, waveResult["valid"].(bool))
+		assert.Equal(t, 0.0, waveResult["hpScore"].(float64))
+		assert.Equal(t, 0.0, waveResult["alienScore"].(float64))
+		assert.Equal(t, 0.0, waveResult["commandScore"].(float64))
+	}
 
 	// The score should have been saved as a valid score,
-	score, valid, found := CLIENT.GetLatestScore(res["id"], models.ALGORITHM_CHALLENGE_TYPE)
+	score, valid, found := CLIENT.GetLatestScore(memberID, models.ALGORITHM_CHALLENGE_TYPE)
 	assert.True(t, valid)
 	assert.True(t, found)
 	assert.Equal(t, 0, score)
 
+	// Along with exactly what was submitted for every wave.
+	submission, found := CLIENT.GetLatestSubmission(memberID)
+	assert.True(t, found)
+	assert.Len(t, submission.Waves, services.NUM_WAVES)
+	for _, wave := range submission.Waves {
+		assert.True(t, wave.IsValid)
+		assert.Equal(t, *wave.OracleHp, *wave.SimulatedHp)
+		assert.Equal(t, wave.OracleCommands, wave.Commands)
+	}
+
 	// CASE 2: USER DOES NOT GIVE ANYTHING
 	serializedAnswers = []map[string]any{}
 	testVerify = CLIENT.AddBody(serializedAnswers).AddHeaders(map[string]string{
 		"Content-Type": "application/json",
-	}).POST("/api/v1/challenge/backend/" + res["id"] + "/aliens/submit")
+	}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
 	testVerify.AssertStatusCode(200, t)
 	testVerify.GetBody(&response, t)
 	assert.False(t, response["valid"].(bool))
 	assert.Equal(t, "Challenge IDs do not match.", response["message"].(string))
+	// Each missing wave is still reported, along with why it failed.
+	assert.Len(t, response["waves"].([]any), services.NUM_WAVES)
 
 	// The score should have been saved as an invalid score.
-	invalidScore, invalid, found := CLIENT.GetLatestScore(res["id"], models.ALGORITHM_CHALLENGE_TYPE)
+	invalidScore, invalid, found := CLIENT.GetLatestScore(memberID, models.ALGORITHM_CHALLENGE_TYPE)
 	assert.True(t, invalid)
 	assert.True(t, found)
 	assert.Equal(t, models.INVALID_SCORE, invalidScore)
 }
+
+func TestBackendAlienChallengeReplay(t *testing.T) {
+	memberID := registerMember(t, map[string]any{
+		"email": "replay@northeastern.edu",
+		"nuid":  NORTHEASTERN_TEST_NUID,
+	})
+
+	var waves []map[string]any
+	CLIENT.GET("/api/v1/challenge/backend/"+memberID+"/aliens").AssertStatusCode(200, t).GetBody(&waves, t)
+	challengeID := waves[0]["challengeID"].(string)
+
+	replay := map[string]any{}
+	CLIENT.GET("/api/v1/challenge/backend/"+memberID+"/aliens/"+challengeID+"/replay?commands=focusedShot,volley").
+		AssertStatusCode(200, t).GetBody(&replay, t)
+	steps := replay["steps"].([]any)
+	// The initial state followed by one step per command.
+	assert.Len(t, steps, 3)
+	assert.Equal(t, waves[0]["hp"], steps[0].(map[string]any)["hp"])
+	assert.Equal(t, "focusedShot", steps[1].(map[string]any)["command"])
+
+	CLIENT.GET("/api/v1/challenge/backend/"+memberID+"/aliens/"+challengeID+"/replay?commands=notACommand").
+		AssertStatusCode(400, t)
+	CLIENT.GET("/api/v1/challenge/backend/"+memberID+"/aliens/"+uuid.NewString()+"/replay").
+		AssertStatusCode(404, t)
+}
+
+func TestBackendAlienChallengeSolutionOnceCohortCloses(t *testing.T) {
+	memberID := registerMember(t, map[string]any{
+		"email": "solution@northeastern.edu",
+		"nuid":  NORTHEASTERN_TEST_NUID,
+	})
+	// The member could still submit the solutions while their cohort is open.
+	CLIENT.GET("/api/v1/challenge/backend/"+memberID+"/aliens/solution").AssertStatusCode(403, t)
+
+	CLIENT.SetMemberCohort(memberID, CLOSED_TEST_COHORT, t)
+	var solutions []map[string]any
+	CLIENT.GET("/api/v1/challenge/backend/"+memberID+"/aliens/solution").AssertStatusCode(200, t).GetBody(&solutions, t)
+	assert.Len(t, solutions, services.NUM_WAVES)
+	for _, solution := range solutions {
+		assert.NotEmpty(t, solution["state"].(map[string]any)["commands"])
+	}
+	CLIENT.AddBody([]map[string]any{}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/challenge/backend/"+memberID+"/aliens/submit").AssertStatusCode(403, t)
+}
@@ -0,0 +1,41 @@
+package integrationtests
+
+import (
+	"fmt"
+	"generate_technical_challenge_2025/internal/database/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlgorithmSubmissionsStopAtAttemptLimit(t *testing.T) {
+	memberID := registerMember(t, map[string]any{
+		"email": "bruteforce@northeastern.edu",
+		"nuid":  "123456789",
+	})
+
+	testVerify := CLIENT.GET("/api/v1/member/" + memberID + "/attempts")
+	testVerify.AssertStatusCode(200, t).AssertBody([]any{
+		map[string]any{"challenge": models.NGROK_CHALLENGE_TYPE, "used": 0.0},
+		map[string]any{"challenge": models.ALGORITHM_CHALLENGE_TYPE, "used": 0.0, "limit": 3.0, "remaining": 3.0},
+	}, t)
+
+	// Invalid submissions count as attempts too.
+	for range TEST_ALGORITHM_MAX_ATTEMPTS {
+		testVerify = CLIENT.AddBody([]map[string]any{}).AddHeaders(map[string]string{
+			"Content-Type": "application/json",
+		}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
+		testVerify.AssertStatusCode(200, t)
+	}
+	testVerify = CLIENT.AddBody([]map[string]any{}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
+	testVerify.AssertStatusCode(403, t).AssertBody(map[string]any{
+		"message": fmt.Sprintf("Attempt limit reached: you have used all %d submissions for this challenge.", TEST_ALGORITHM_MAX_ATTEMPTS),
+	}, t)
+
+	testVerify = CLIENT.GET("/api/v1/member/" + memberID + "/attempts")
+	var attempts []map[string]any
+	testVerify.AssertStatusCode(200, t).GetBody(&attempts, t)
+	assert.Equal(t, map[string]any{"challenge": models.ALGORITHM_CHALLENGE_TYPE, "used": 3.0, "limit": 3.0, "remaining": 0.0}, attempts[1])
+}
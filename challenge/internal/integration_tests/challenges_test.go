@@ -0,0 +1,19 @@
+package integrationtests
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChallengesListsEveryRegisteredChallenge(t *testing.T) {
+	testVerify := CLIENT.GET("/api/v1/challenges")
+	testVerify.AssertStatusCode(200, t)
+	var res []map[string]string
+	testVerify.GetBody(&res, t)
+	assert.Equal(t, []map[string]string{
+		{"type": models.NGROK_CHALLENGE_TYPE, "title": "ngrok Challenge"},
+		{"type": models.ALGORITHM_CHALLENGE_TYPE, "title": "Alien Invasion Challenge"},
+	}, res)
+}
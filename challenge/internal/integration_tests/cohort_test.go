@@ -0,0 +1,63 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCohortsListsOpenAndClosedCohorts(t *testing.T) {
+	testVerify := CLIENT.GET("/api/v1/cohorts")
+	testVerify.AssertStatusCode(200, t)
+	var res []map[string]any
+	testVerify.GetBody(&res, t)
+	open := map[string]bool{}
+	for _, cohort := range res {
+		open[cohort["name"].(string)] = cohort["open"].(bool)
+	}
+	assert.Equal(t, map[string]bool{TEST_COHORT: true, CLOSED_TEST_COHORT: false}, open)
+}
+
+func TestRegisterIntoClosedCohortReceives403(t *testing.T) {
+	client := CLIENT.AddBody(map[string]any{
+		"email":  "toolate@northeastern.edu",
+		"nuid":   "123456789",
+		"cohort": CLOSED_TEST_COHORT,
+	}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	})
+	testVerify := client.POST("/api/v1/member/register")
+	testVerify.AssertStatusCode(403, t).AssertBody(map[string]any{
+		"message": "Registration is closed.",
+	}, t)
+}
+
+func TestRegisterIntoUnknownCohortReceives404(t *testing.T) {
+	client := CLIENT.AddBody(map[string]any{
+		"email":  "lost@northeastern.edu",
+		"nuid":   "123456789",
+		"cohort": "Summer 1999",
+	}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	})
+	testVerify := client.POST("/api/v1/member/register")
+	testVerify.AssertStatusCode(404, t)
+}
+
+func TestMemberLookupByCohort(t *testing.T) {
+	registerMember(t, map[string]any{
+		"email":  "cohortmember@northeastern.edu",
+		"nuid":   "123456789",
+		"cohort": TEST_COHORT,
+	})
+
+	testVerify := CLIENT.AddBody(map[string]any{
+		"email":  "cohortmember@northeastern.edu",
+		"nuid":   "123456789",
+		"cohort": CLOSED_TEST_COHORT,
+		"code":   "000000",
+	}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/member/verify")
+	testVerify.AssertStatusCode(404, t)
+}
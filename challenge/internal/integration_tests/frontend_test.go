@@ -52,28 +52,12 @@ func registerForChallenge(t *testing.T) {
 	if testMemberAlreadyRegistered {
 		return
 	}
-	client := CLIENT.AddBody(map[string]any{
+	// Make sure that the member is successfully registered before preceding in test suite.
+	// Assign the global memberUUID for future use in testing.
+	memberUUID = uuid.MustParse(registerMember(t, map[string]any{
 		"email": "somefrontendperson@northeastern.edu",
 		"nuid":  "123456789",
-	}).AddHeaders(map[string]string{
-		"Content-Type": "application/json",
-	})
-
-	testVerify := client.POST("/api/v1/member/register")
-	pred := func(prop any) bool {
-		s, ok := prop.(string)
-		if !ok {
-			return ok
-		}
-
-		// Assign the global memberUUID for future use in testing.
-		var err error
-		memberUUID, err = uuid.Parse(s)
-		return err == nil
-	}
-
-	// Make sure that the member is successfully registered before preceding in test suite.
-	testVerify.AssertStatusCode(201, t).AssertProperty("id", pred, t)
+	}))
 	testMemberAlreadyRegistered = true
 }
 
@@ -0,0 +1,41 @@
+package integrationtests
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboardHidesEmails(t *testing.T) {
+	memberID := registerMember(t, map[string]any{
+		"email": "leaderboard@northeastern.edu",
+		"nuid":  "123456789",
+	})
+
+	// Invalid submissions never make it onto the leaderboard.
+	testVerify := CLIENT.AddBody([]map[string]any{}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
+	testVerify.AssertStatusCode(200, t)
+
+	testVerify = CLIENT.GET("/api/v1/leaderboard?challenge=" + models.ALGORITHM_CHALLENGE_TYPE + "&cohort=" + url.QueryEscape(TEST_COHORT))
+	var leaderboard map[string]any
+	testVerify.AssertStatusCode(200, t).GetBody(&leaderboard, t)
+	assert.Equal(t, models.ALGORITHM_CHALLENGE_TYPE, leaderboard["challenge"])
+	assert.EqualValues(t, services.CURRENT_CHALLENGE_VERSION, leaderboard["challengeVersion"])
+	handle := services.MemberHandle(uuid.MustParse(memberID))
+	for _, entry := range leaderboard["entries"].([]any) {
+		assert.NotContains(t, entry, "email")
+		assert.NotEqual(t, handle, entry.(map[string]any)["handle"])
+	}
+}
+
+func TestLeaderboardRejectsUnknownChallengesAndCohorts(t *testing.T) {
+	CLIENT.GET("/api/v1/leaderboard?challenge=unknown").AssertStatusCode(400, t)
+	CLIENT.GET("/api/v1/leaderboard?challenge="+models.NGROK_CHALLENGE_TYPE+"&cohort=unknown").AssertStatusCode(404, t)
+	CLIENT.GET("/api/v1/leaderboard").AssertStatusCode(400, t)
+}
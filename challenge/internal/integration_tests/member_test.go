@@ -1,9 +1,12 @@
 package integrationtests
 
 import (
+	"generate_technical_challenge_2025/internal/handler"
+	"maps"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestUserWithNonValidNUIDReceives400(t *testing.T) {
@@ -32,15 +35,21 @@ func TestUserWithBadNUIDReceives400(t *testing.T) {
 	}, t)
 }
 
-func TestUserReceives201OnGoodRequest(t *testing.T) {
-	client := CLIENT.AddBody(map[string]any{
+func TestUserReceives202AndVerifiesWithEmailedCode(t *testing.T) {
+	member := map[string]any{
 		"email": "somebody@northeastern.edu",
 		"nuid":  "123456789", // NUID is 9 characters long
-	}).AddHeaders(map[string]string{
-		"Content-Type": "application/json",
-	})
+	}
+	headers := map[string]string{"Content-Type": "application/json"}
+
+	testVerify := CLIENT.AddBody(member).AddHeaders(headers).POST("/api/v1/member/register")
+	testVerify.AssertStatusCode(202, t)
+	code := MAILER.Code("somebody@northeastern.edu")
+	assert.Len(t, code, 6)
+
+	testVerify = CLIENT.AddBody(withCode(member, wrongCode(code))).AddHeaders(headers).POST("/api/v1/member/verify")
+	testVerify.AssertStatusCode(400, t).AssertBody(map[string]any{"message": "Invalid or expired code."}, t)
 
-	testVerify := client.POST("/api/v1/member/register")
 	pred := func(prop any) bool {
 		s, ok := prop.(string)
 		if !ok {
@@ -49,54 +58,110 @@ func TestUserReceives201OnGoodRequest(t *testing.T) {
 		_, err := uuid.Parse(s)
 		return err == nil
 	}
-	testVerify.AssertStatusCode(201, t).AssertProperty("id", pred, t)
+	testVerify = CLIENT.AddBody(withCode(member, code)).AddHeaders(headers).POST("/api/v1/member/verify")
+	testVerify.AssertStatusCode(200, t).AssertProperty("id", pred, t)
+
+	// Codes only work once.
+	testVerify = CLIENT.AddBody(withCode(member, code)).AddHeaders(headers).POST("/api/v1/member/verify")
+	testVerify.AssertStatusCode(400, t)
 }
 
 func TestMemberCannotRegisterTwice(t *testing.T) {
-	client := CLIENT.AddBody(map[string]any{
+	member := map[string]any{
 		"email": "hasneverregisteredbefore@northeastern.edu",
 		"nuid":  "123456789", // NUID is 9 characters long
-	}).AddHeaders(map[string]string{
+	}
+	registerMember(t, member)
+	testVerify := CLIENT.AddBody(member).AddHeaders(map[string]string{
 		"Content-Type": "application/json",
-	})
+	}).POST("/api/v1/member/register")
+	testVerify.AssertStatusCode(409, t)
+}
 
-	firstRegistration := client.POST("/api/v1/member/register")
-	firstRegistration.AssertStatusCode(201, t)
-	nextClient := CLIENT.AddBody(map[string]any{
-		"email": "hasneverregisteredbefore@northeastern.edu",
-		"nuid":  "123456789", // NUID is 9 characters long
+func TestNewCodesAreRateLimited(t *testing.T) {
+	client := CLIENT.AddBody(map[string]any{
+		"email": "impatient@northeastern.edu",
+		"nuid":  "123456789",
 	}).AddHeaders(map[string]string{
 		"Content-Type": "application/json",
 	})
-	testVerify := nextClient.POST("/api/v1/member/register")
-	testVerify.AssertStatusCode(409, t)
+	client.POST("/api/v1/member/register").AssertStatusCode(202, t)
+	// Registering again before verifying sends a new code, as does asking for one.
+	client.POST("/api/v1/member/register").AssertStatusCode(429, t)
+	client.POST("/api/v1/member/code").AssertStatusCode(429, t)
 }
 
-func TestMemberGets200IfUserIsFoundInDatabase(t *testing.T) {
-	client := CLIENT
-	testVerifyGET := client.GET("/api/v1/member?email=somebody%40northeastern.edu&nuid=123456789")
-	pred := func(prop any) bool {
-		s, ok := prop.(string)
-		if !ok {
-			return ok
-		}
-		_, err := uuid.Parse(s)
-		return err == nil
+func TestVerificationStopsAfterTooManyWrongCodes(t *testing.T) {
+	member := map[string]any{
+		"email": "guesser@northeastern.edu",
+		"nuid":  "123456789",
 	}
-	testVerifyGET.AssertStatusCode(200, t).AssertProperty("id", pred, t)
+	headers := map[string]string{"Content-Type": "application/json"}
+	CLIENT.AddBody(member).AddHeaders(headers).POST("/api/v1/member/register").AssertStatusCode(202, t)
+	code := MAILER.Code("guesser@northeastern.edu")
+
+	for range TEST_VERIFICATION_MAX_ATTEMPTS {
+		testVerify := CLIENT.AddBody(withCode(member, wrongCode(code))).AddHeaders(headers).POST("/api/v1/member/verify")
+		testVerify.AssertStatusCode(400, t)
+	}
+	// Even the right code is refused until a new one is sent.
+	testVerify := CLIENT.AddBody(withCode(member, code)).AddHeaders(headers).POST("/api/v1/member/verify")
+	testVerify.AssertStatusCode(429, t)
 }
 
-func TestMemberGets400ForMalformedNUIDOrEmail(t *testing.T) {
-	client := CLIENT
-	testVerifyGET := client.GET("/api/v1/member?email=somebody%40gmail.com&nuid=2134")
+func TestUnverifiedMemberCannotSubmit(t *testing.T) {
+	testVerify := CLIENT.AddBody(map[string]any{
+		"email": "unverified@northeastern.edu",
+		"nuid":  "123456789",
+	}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/member/register")
+	testVerify.AssertStatusCode(202, t)
+
+	// Ids are only handed out once verified, so look it up the way an interviewer would.
+	var members []map[string]any
+	CLIENT.AddHeaders(bearer(TEST_INTERVIEWER_TOKEN)).GET("/api/v1/admin/members?email=unverified@northeastern.edu").
+		AssertStatusCode(200, t).GetBody(&members, t)
+	memberID := members[0]["id"].(string)
 
-	testVerifyGET.AssertStatusCode(400, t)
-	testVerifyGET = client.GET("/api/v1/member?email=somebody%40northeastern.com&nuid=1234")
-	testVerifyGET.AssertStatusCode(400, t)
+	testVerify = CLIENT.AddBody([]map[string]any{}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
+	testVerify.AssertStatusCode(403, t).AssertBody(map[string]any{"message": handler.PENDING_VERIFICATION_MESSAGE}, t)
+}
+
+func TestMemberGets400ForMalformedNUIDOrEmail(t *testing.T) {
+	client := CLIENT.AddHeaders(map[string]string{"Content-Type": "application/json"})
+	for _, endpoint := range []string{"/api/v1/member/code", "/api/v1/member/verify"} {
+		testVerify := client.AddBody(map[string]any{"email": "somebody@gmail.com", "nuid": "2134", "code": "000000"}).POST(endpoint)
+		testVerify.AssertStatusCode(400, t)
+		testVerify = client.AddBody(map[string]any{"email": "somebody@northeastern.com", "nuid": "1234", "code": "000000"}).POST(endpoint)
+		testVerify.AssertStatusCode(400, t)
+	}
 }
 
 func TestMemberGets404IfNotFound(t *testing.T) {
-	client := CLIENT
-	testVerifyGET := client.GET("/api/v1/member?email=somebodyNotExist%40northeastern.edu&nuid=123456789")
-	testVerifyGET.AssertStatusCode(404, t)
+	client := CLIENT.AddBody(map[string]any{
+		"email": "somebodyNotExist@northeastern.edu",
+		"nuid":  "123456789",
+		"code":  "000000",
+	}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	})
+	client.POST("/api/v1/member/code").AssertStatusCode(404, t)
+	client.POST("/api/v1/member/verify").AssertStatusCode(404, t)
+}
+
+func withCode(member map[string]any, code string) map[string]any {
+	verification := maps.Clone(member)
+	verification["code"] = code
+	return verification
+}
+
+// Any code other than the given one.
+func wrongCode(code string) string {
+	if code == "000000" {
+		return "000001"
+	}
+	return "000000"
 }
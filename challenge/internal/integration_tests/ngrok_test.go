@@ -0,0 +1,62 @@
+package integrationtests
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/reference"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNgrokSubmissionIsGradedAsAJob(t *testing.T) {
+	// Stores whatever is posted, but ignores every filter.
+	candidate := reference.CreateServer(reference.Faults{IgnoreFilters: true, AcceptInvalidAliens: true})
+	defer candidate.Close()
+
+	memberID := registerMember(t, map[string]any{
+		"email": "ngrokperson@northeastern.edu",
+		"nuid":  "123456789",
+	})
+
+	testVerify := CLIENT.AddBody(map[string]any{"url": candidate.URL}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/challenge/backend/" + memberID + "/ngrok/submit")
+	testVerify.AssertStatusCode(202, t)
+	var submitted map[string]any
+	testVerify.GetBody(&submitted, t)
+	jobID := submitted["jobId"].(string)
+
+	// Poll until the workers have graded the job.
+	var job map[string]any
+	require.Eventually(t, func() bool {
+		testVerify := CLIENT.GET("/api/v1/challenge/backend/" + memberID + "/ngrok/jobs/" + jobID)
+		testVerify.AssertStatusCode(200, t)
+		job = map[string]any{}
+		testVerify.GetBody(&job, t)
+		return job["status"] == models.NGROK_JOB_DONE || job["status"] == models.NGROK_JOB_FAILED
+	}, 30*time.Second, 100*time.Millisecond)
+
+	assert.Equal(t, models.NGROK_JOB_DONE, job["status"])
+	assert.True(t, job["valid"].(bool))
+	assert.NotEmpty(t, job["checks"])
+	assert.Contains(t, job, "latencyP95Ms")
+
+	score, isValid, found := CLIENT.GetLatestScore(memberID, models.NGROK_CHALLENGE_TYPE)
+	assert.True(t, found)
+	assert.True(t, isValid)
+	assert.Equal(t, job["score"].(float64), float64(score))
+
+	// The latency is kept in the score history.
+	var history []map[string]any
+	CLIENT.AddHeaders(bearer(TEST_INTERVIEWER_TOKEN)).GET("/api/v1/admin/members/"+memberID+"/history").
+		AssertStatusCode(200, t).GetBody(&history, t)
+	require.Len(t, history, 1)
+	assert.Equal(t, job["latencyP50Ms"], history[0]["latencyP50Ms"])
+	assert.Equal(t, job["latencyP95Ms"], history[0]["latencyP95Ms"])
+
+	// Jobs cannot be polled through another member's ID.
+	CLIENT.GET("/api/v1/challenge/backend/00000000-0000-0000-0000-000000000000/ngrok/jobs/"+jobID).
+		AssertStatusCode(404, t)
+}
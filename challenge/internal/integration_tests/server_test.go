@@ -12,6 +12,8 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"regexp"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,12 +22,60 @@ import (
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 )
 
+const (
+	TEST_COHORT        = "Generate 2025"
+	CLOSED_TEST_COHORT = "Spring 2025"
+
+	TEST_ALGORITHM_MAX_ATTEMPTS = 3
+
+	TEST_INTERVIEWER_TOKEN = "test-interviewer-token"
+	TEST_ADMIN_TOKEN       = "test-admin-token"
+
+	TEST_VERIFICATION_CODE_TTL     = 15 * time.Minute
+	TEST_VERIFICATION_RESEND_DELAY = time.Minute
+	TEST_VERIFICATION_MAX_ATTEMPTS = 3
+)
+
 var (
 	PORT   = 8008
 	LOGGER = slog.New(slog.Default().Handler())
 	CLIENT = utils.CreateTestClient(PORT, LOGGER)
+	MAILER = &recordingMailer{codes: map[string]string{}}
 )
 
+// Keeps the latest verification code emailed to each address, so tests can verify members.
+type recordingMailer struct {
+	mu    sync.Mutex
+	codes map[string]string
+}
+
+var verificationCodePattern = regexp.MustCompile(`\b\d{6}\b`)
+
+// Send implements services.Mailer.
+func (m *recordingMailer) Send(ctx context.Context, email services.Email) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.codes[email.To] = verificationCodePattern.FindString(email.Body)
+	return nil
+}
+
+// Latest code emailed to the address, or "" if none was.
+func (m *recordingMailer) Code(email string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.codes[email]
+}
+
+// Registers a member with the email, nuid and optional cohort in body, verifies their email and returns their id.
+func registerMember(t *testing.T, body map[string]any) string {
+	headers := map[string]string{"Content-Type": "application/json"}
+	CLIENT.AddBody(body).AddHeaders(headers).POST("/api/v1/member/register").AssertStatusCode(202, t)
+
+	var res map[string]string
+	CLIENT.AddBody(withCode(body, MAILER.Code(body["email"].(string)))).AddHeaders(headers).POST("/api/v1/member/verify").AssertStatusCode(200, t).GetBody(&res, t)
+	return res["id"]
+}
+
 func runTestServer() {
 	ctx := context.Background()
 
@@ -57,13 +107,20 @@ func runTestServer() {
 	dbPort = utils.FatalCall(dbHostPortFn).Port()
 
 	envConfig := &utils.EnvConfig{
-		DB_HOST:       dbHost,
-		DB_PORT:       dbPort,
-		DB_USER:       dbUser,
-		DB_PASSWORD:   dbPassword,
-		DB_NAME:       dbName,
-		PORT:          PORT,
-		SLACK_WEBHOOK: "",
+		DB_HOST:                   dbHost,
+		DB_PORT:                   dbPort,
+		DB_USER:                   dbUser,
+		DB_PASSWORD:               dbPassword,
+		DB_NAME:                   dbName,
+		PORT:                      PORT,
+		SLACK_WEBHOOK:             "",
+		DEFAULT_COHORT:            TEST_COHORT,
+		ALGORITHM_MAX_ATTEMPTS:    TEST_ALGORITHM_MAX_ATTEMPTS,
+		INTERVIEWER_TOKENS:        []string{TEST_INTERVIEWER_TOKEN},
+		ADMIN_TOKENS:              []string{TEST_ADMIN_TOKEN},
+		VERIFICATION_CODE_TTL:     TEST_VERIFICATION_CODE_TTL,
+		VERIFICATION_RESEND_DELAY: TEST_VERIFICATION_RESEND_DELAY,
+		VERIFICATION_MAX_ATTEMPTS: TEST_VERIFICATION_MAX_ATTEMPTS,
 	}
 	db := database.CreateDatabase(*envConfig, LOGGER)
 
@@ -73,12 +130,28 @@ func runTestServer() {
 
 	memberTransactions := transactions.CreateMemberTransactions(LOGGER, db)
 	challengeTransactions := transactions.CreateChallengeTransactions(LOGGER, db)
+	cohortTransactions := transactions.CreateCohortTransactions(LOGGER, db)
+
+	memberServices := services.CreateMemberService(LOGGER, memberTransactions, envConfig.ChallengeAttemptLimits())
+	challengeServices := services.CreateChallengeService(LOGGER, challengeTransactions,
+		services.CreateNgrokLatencyConfig(*envConfig), true)
+	utils.FatalCallErrorSupplier(func() error { return challengeServices.StartNgrokWorkers(ctx, 2, memberServices) })
 
-	memberServices := services.CreateMemberService(LOGGER, memberTransactions)
-	challengeServices := services.CreateChallengeService(LOGGER, challengeTransactions)
+	challenges := services.CreateChallengeRegistry(challengeServices)
+	leaderboardServices := services.CreateLeaderboardService(LOGGER, memberTransactions, cohortTransactions, challenges, services.CreateLeaderboardConfig(*envConfig))
+	verificationServices := services.CreateVerificationService(LOGGER, memberTransactions, MAILER, services.CreateVerificationConfig(*envConfig))
+	cohortServices := services.CreateCohortService(LOGGER, cohortTransactions, challenges.Types())
+	utils.FatalCallErrorSupplier(func() error {
+		return cohortServices.SyncCohorts([]services.CohortConfig{
+			{Name: TEST_COHORT},
+			// Already closed, so registering without a cohort still picks the test cohort.
+			{Name: CLOSED_TEST_COHORT, OpensAt: time.Now().Add(-2 * time.Hour), ClosesAt: time.Now().Add(-time.Hour)},
+		}, envConfig.DEFAULT_COHORT)
+	})
 
-	h := handler.CreateHandler(LOGGER, memberServices, challengeServices)
-	server.RunServer(h, *envConfig, LOGGER)
+	h := handler.CreateHandler(LOGGER, memberServices, challengeServices, cohortServices, leaderboardServices, verificationServices, challenges)
+	security := handler.CreateSecurityHandler(services.CreateStaffAuthenticator(envConfig.INTERVIEWER_TOKENS, envConfig.ADMIN_TOKENS))
+	server.RunServer(h, security, *envConfig, LOGGER)
 }
 
 func TestMain(m *testing.M) {
@@ -0,0 +1,60 @@
+package integrationtests
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberStatusTracksSubmissions(t *testing.T) {
+	memberID := registerMember(t, map[string]any{
+		"email": "status@northeastern.edu",
+		"nuid":  "123456789",
+	})
+
+	// Max scores depend on the member, so they are checked separately.
+	var initial map[string]any
+	CLIENT.GET("/api/v1/member/"+memberID+"/status").AssertStatusCode(200, t).GetBody(&initial, t)
+	for _, challenge := range initial["challenges"].([]any) {
+		assert.Positive(t, challenge.(map[string]any)["maxScore"])
+		delete(challenge.(map[string]any), "maxScore")
+	}
+	assert.Equal(t, map[string]any{
+		"cohort":           TEST_COHORT,
+		"submissionTokens": 10.0,
+		"frontendFetched":  false,
+		"challenges": []any{
+			map[string]any{"challenge": models.NGROK_CHALLENGE_TYPE, "title": "ngrok Challenge", "attempts": 0.0},
+			map[string]any{"challenge": models.ALGORITHM_CHALLENGE_TYPE, "title": "Alien Invasion Challenge", "attempts": 0.0},
+		},
+	}, initial)
+
+	testVerify := CLIENT.AddBody([]map[string]any{}).AddHeaders(map[string]string{
+		"Content-Type": "application/json",
+	}).POST("/api/v1/challenge/backend/" + memberID + "/aliens/submit")
+	testVerify.AssertStatusCode(200, t)
+
+	testVerify = CLIENT.GET("/api/v1/member/" + memberID + "/status")
+	var status map[string]any
+	testVerify.AssertStatusCode(200, t).GetBody(&status, t)
+	assert.Equal(t, 9.0, status["submissionTokens"])
+	algorithm := status["challenges"].([]any)[1].(map[string]any)
+	assert.Equal(t, 1.0, algorithm["attempts"])
+	assert.Equal(t, false, algorithm["latestValid"])
+	assert.NotContains(t, algorithm, "bestScore")
+	assert.NotContains(t, algorithm, "latestScore")
+	submittedAt, err := time.Parse(time.RFC3339, algorithm["lastSubmittedAt"].(string))
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), submittedAt, time.Minute)
+
+	// Attempts agree with what counts against the limit.
+	var attempts []map[string]any
+	CLIENT.GET("/api/v1/member/"+memberID+"/attempts").AssertStatusCode(200, t).GetBody(&attempts, t)
+	for idx, quota := range attempts {
+		assert.Equal(t, quota["used"], status["challenges"].([]any)[idx].(map[string]any)["attempts"], quota["challenge"])
+	}
+
+	CLIENT.GET("/api/v1/member/00000000-0000-0000-0000-000000000000/status").AssertStatusCode(404, t)
+}
@@ -0,0 +1,245 @@
+// Package reference is an in-memory implementation of the server candidates build for the ngrok challenge,
+// used to check that the grader gives a correct server a perfect score.
+package reference
+
+import (
+	"cmp"
+	"encoding/json"
+	"generate_technical_challenge_2025/internal/services"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Ways to break the server, to check what the grader deducts for them. The zero value is a correct server.
+type Faults struct {
+	OffByOneFilters     bool                     // Treats _lte and _gte as < and >.
+	IgnoreTypeFilter    bool                     // Treats type= as an unknown parameter.
+	IgnoreFilters       bool                     // Treats every parameter of GET /api/aliens as unknown.
+	AcceptInvalidAliens bool                     // Stores every alien posted in an array, without validating it.
+	PostReturnsOK       bool                     // Responds to a successful POST with a 200 instead of a 201.
+	ExtraAliens         []services.DetailedAlien // Appended to every GET /api/aliens response.
+}
+
+type server struct {
+	faults Faults
+	mu     sync.Mutex
+	aliens []services.DetailedAlien // In the order they were posted.
+}
+
+var (
+	alienTypes          = []services.AlienType{services.AlienTypeRegular, services.AlienTypeElite, services.AlienTypeBoss}
+	numericFields       = []string{services.SPD, services.ATK, services.HP}
+	supportedParameters = []string{
+		services.QUERY_TYPE, services.QUERY_LIMIT, services.QUERY_OFFSET, services.QUERY_SORT, services.QUERY_ORDER,
+		"spd_lte", "spd_gte", "atk_lte", "atk_gte", "hp_lte", "hp_gte",
+	}
+)
+
+// Creates a handler for GET /healthcheck and every /api/aliens endpoint, storing the aliens in memory.
+func CreateHandler(faults Faults) http.Handler {
+	s := &server{faults: faults, aliens: []services.DetailedAlien{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthcheck", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET "+services.NGROK_PATH, s.getAliens)
+	mux.HandleFunc("POST "+services.NGROK_PATH, s.postAliens)
+	mux.HandleFunc("DELETE "+services.NGROK_PATH, s.deleteAliens)
+	mux.HandleFunc("GET "+services.NGROK_PATH+"/{id}", s.getAlien)
+	mux.HandleFunc("PATCH "+services.NGROK_PATH+"/{id}", s.patchAlien)
+	mux.HandleFunc("DELETE "+services.NGROK_PATH+"/{id}", s.deleteAlien)
+	return mux
+}
+
+// Starts a server with CreateHandler, which must be closed by the caller.
+func CreateServer(faults Faults) *httptest.Server {
+	return httptest.NewServer(CreateHandler(faults))
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func (s *server) deleteAliens(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.aliens = []services.DetailedAlien{}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Stores every alien, or none of them if any is invalid.
+func (s *server) postAliens(w http.ResponseWriter, r *http.Request) {
+	var aliens []services.DetailedAlien
+	if err := json.NewDecoder(r.Body).Decode(&aliens); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, alien := range aliens {
+		if !s.faults.AcceptInvalidAliens && (alien.ID == "" || alien.Spd < 0 || alien.BaseAlien.Atk < 0 || alien.BaseAlien.Hp < 0 ||
+			!slices.Contains(alienTypes, alien.Type) || s.indexOf(alien.ID) != -1) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	s.aliens = append(s.aliens, aliens...)
+
+	if s.faults.PostReturnsOK {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *server) getAliens(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	values := map[string]string{}
+	for parameter, given := range query {
+		// Unknown parameters are ignored, even when repeated.
+		if s.faults.IgnoreFilters || !slices.Contains(supportedParameters, parameter) ||
+			(parameter == services.QUERY_TYPE && s.faults.IgnoreTypeFilter) {
+			continue
+		}
+		if len(given) > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		values[parameter] = given[0]
+	}
+
+	s.mu.Lock()
+	aliens := slices.Clone(s.aliens)
+	s.mu.Unlock()
+
+	if alienType, ok := values[services.QUERY_TYPE]; ok {
+		if !slices.Contains(alienTypes, services.AlienType(alienType)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		aliens = slices.DeleteFunc(aliens, func(alien services.DetailedAlien) bool { return string(alien.Type) != alienType })
+	}
+
+	for _, field := range numericFields {
+		for _, op := range []string{"lte", "gte"} {
+			value, ok := values[field+"_"+op]
+			if !ok {
+				continue
+			}
+			bound, err := strconv.Atoi(value)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if s.faults.OffByOneFilters {
+				bound += map[string]int{"lte": -1, "gte": 1}[op]
+			}
+			aliens = slices.DeleteFunc(aliens, func(alien services.DetailedAlien) bool {
+				if op == "lte" {
+					return fieldValue(alien, field) > bound
+				}
+				return fieldValue(alien, field) < bound
+			})
+		}
+	}
+
+	if field, ok := values[services.QUERY_SORT]; ok {
+		order := cmp.Or(values[services.QUERY_ORDER], services.SORT_ASC)
+		if !slices.Contains(numericFields, field) || (order != services.SORT_ASC && order != services.SORT_DESC) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		slices.SortStableFunc(aliens, func(a, b services.DetailedAlien) int {
+			if order == services.SORT_DESC {
+				a, b = b, a
+			}
+			return cmp.Compare(fieldValue(a, field), fieldValue(b, field))
+		})
+	}
+
+	offset, ok := nonNegative(values, services.QUERY_OFFSET, 0)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	aliens = aliens[min(offset, len(aliens)):]
+	limit, ok := nonNegative(values, services.QUERY_LIMIT, len(aliens))
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	aliens = aliens[:min(limit, len(aliens))]
+
+	writeJSON(w, http.StatusOK, append(aliens, s.faults.ExtraAliens...))
+}
+
+func (s *server) getAlien(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx := s.indexOf(r.PathValue("id"))
+	if idx == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.aliens[idx])
+}
+
+// Only updates the fields given in the body.
+func (s *server) patchAlien(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx := s.indexOf(r.PathValue("id"))
+	if idx == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	updated := s.aliens[idx]
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil || updated.ID != s.aliens[idx].ID {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.aliens[idx] = updated
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *server) deleteAlien(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx := s.indexOf(r.PathValue("id"))
+	if idx == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.aliens = slices.Delete(s.aliens, idx, idx+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Returns the index of the first alien with the ID, or -1. Must be called with the lock held.
+func (s *server) indexOf(id string) int {
+	return slices.IndexFunc(s.aliens, func(alien services.DetailedAlien) bool { return alien.ID == id })
+}
+
+func fieldValue(alien services.DetailedAlien, field string) int {
+	switch field {
+	case services.ATK:
+		return alien.BaseAlien.Atk
+	case services.HP:
+		return alien.BaseAlien.Hp
+	}
+	return alien.Spd
+}
+
+func nonNegative(values map[string]string, parameter string, fallback int) (int, bool) {
+	value, ok := values[parameter]
+	if !ok {
+		return fallback, true
+	}
+	parsed, err := strconv.Atoi(value)
+	return parsed, err == nil && parsed >= 0
+}
@@ -1,23 +1,31 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	api "generate_technical_challenge_2025/internal/api"
+	"generate_technical_challenge_2025/internal/services"
 	"generate_technical_challenge_2025/internal/utils"
 	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/ogen-go/ogen/ogenerrors"
 )
 
 // Runs the server api with the given handler.
-func RunServer(handler api.Handler, cfg utils.EnvConfig, logger *slog.Logger) {
+func RunServer(h api.Handler, security api.SecurityHandler, cfg utils.EnvConfig, logger *slog.Logger) {
 	// Create middleware for logging.
-	opts := api.WithMiddleware(
-		logging(logger),
-		slackErrorMiddleware(cfg.SLACK_WEBHOOK),
-		// I would set this lower than 10 seconds, but the ngrok challenge is slow because it
-		// has to make many http requests to the ngrok server, averaging 6-7 seconds.
-		slowRequestMiddleware(10*time.Second, cfg.SLACK_WEBHOOK))
+	opts := []api.ServerOption{
+		api.WithMiddleware(
+			logging(logger),
+			slackErrorMiddleware(cfg.SLACK_WEBHOOK),
+			// The ngrok challenge is graded in the background, so no request should come close to this.
+			slowRequestMiddleware(3*time.Second, cfg.SLACK_WEBHOOK)),
+		api.WithErrorHandler(errorHandler),
+	}
 
 	mux := http.NewServeMux()
 
@@ -26,7 +34,7 @@ func RunServer(handler api.Handler, cfg utils.EnvConfig, logger *slog.Logger) {
 	})
 
 	// Create server
-	srvFunc := func() (*api.Server, error) { return api.NewServer(handler, opts) }
+	srvFunc := func() (*api.Server, error) { return api.NewServer(h, security, opts...) }
 	srv := utils.FatalCall(srvFunc)
 	mux.Handle("/", srv)
 
@@ -52,3 +60,20 @@ func RunServer(handler api.Handler, cfg utils.EnvConfig, logger *slog.Logger) {
 	// Run server indefinitely
 	utils.FatalCallErrorSupplier(servFunc)
 }
+
+// Responds to failed staff authentication with the same error body as every other endpoint, leaving every other
+// error to ogen.
+func errorHandler(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	var securityErr *ogenerrors.SecurityError
+	if !errors.As(err, &securityErr) {
+		ogenerrors.DefaultErrorHandler(ctx, w, r, err)
+		return
+	}
+	status, message := http.StatusUnauthorized, "Missing or invalid staff token."
+	if errors.Is(err, services.ErrRoleNotAllowed) {
+		status, message = http.StatusForbidden, "Your role is not allowed to do this."
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
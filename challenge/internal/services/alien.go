@@ -1,9 +1,12 @@
 package services
 
 import (
+	"fmt"
 	"generate_technical_challenge_2025/internal/utils"
 	"math/rand"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/samber/lo"
 )
@@ -51,25 +54,69 @@ func CreateInvasionState(aliens []Alien, startingHp int) InvasionState {
 	}.sortAliens()
 }
 
+// Runs a single command against the invasion, after which the remaining aliens attack.
+func (i InvasionState) executeCommand(command string) InvasionState {
+	mappings := map[string]func() InvasionState{
+		VOLLEY:         i.AttackAliensModulo,
+		FOCUSED_VOLLEY: i.AttackHighestDamagingHalf,
+		FOCUSED_SHOT:   i.AttackHighestDamageAlien,
+	}
+	return mappings[command]().sortAliens().AliensAttack()
+}
+
 func RunCommandsToCompletion(startingState InvasionState, commands []string) *InvasionState {
 	state := startingState
-	mapFunc := func(s InvasionState, command string) InvasionState {
-		mappings := map[string]func() InvasionState{
-			VOLLEY:         s.AttackAliensModulo,
-			FOCUSED_VOLLEY: s.AttackHighestDamagingHalf,
-			FOCUSED_SHOT:   s.AttackHighestDamageAlien,
-		}
-		return mappings[command]().sortAliens().AliensAttack()
-	}
 	for _, command := range commands {
 		if state.IsOver() {
 			return nil
 		}
-		state = mapFunc(state, command)
+		state = state.executeCommand(command)
 	}
 	return &state
 }
 
+// One step of a replayed invasion.
+type ReplayStep struct {
+	Command      string // Empty for the initial state.
+	HitAliens    []int  // Indices into the previous step's aliens that took damage.
+	KilledAliens []int  // Indices into the previous step's aliens that were killed.
+	State        InvasionState
+}
+
+// Runs the commands through the same pipeline as RunCommandsToCompletion, recording every intermediate state
+// along with which aliens each command hit. The first step is always the starting state. Returns false if the
+// invasion ended before every command could be executed.
+func ReplayCommands(startingState InvasionState, commands []string) ([]ReplayStep, bool) {
+	state := startingState.sortAliens()
+	steps := []ReplayStep{{HitAliens: []int{}, KilledAliens: []int{}, State: state}}
+	for _, command := range commands {
+		if state.IsOver() {
+			return steps, false
+		}
+		// Every command targets a prefix of the sorted aliens.
+		var targeted, damage int
+		switch command {
+		case VOLLEY:
+			targeted, damage = state.hpLeft%state.GetAliensLeft(), 1
+		case FOCUSED_VOLLEY:
+			targeted, damage = (state.GetAliensLeft()+1)/2, 2
+		case FOCUSED_SHOT:
+			targeted, damage = 1, state.GetCurrentHighestDamagingAlien().Hp
+		}
+		step := ReplayStep{Command: command, HitAliens: []int{}, KilledAliens: []int{}}
+		for idx, alien := range state.aliensLeft[:targeted] {
+			step.HitAliens = append(step.HitAliens, idx)
+			if alien.TakeDamage(damage).Hp <= 0 {
+				step.KilledAliens = append(step.KilledAliens, idx)
+			}
+		}
+		state = state.executeCommand(command)
+		step.State = state
+		steps = append(steps, step)
+	}
+	return steps, true
+}
+
 func (i InvasionState) GetNumberOfCommandsUsed() int {
 	return len(i.commands)
 }
@@ -116,7 +163,12 @@ func RunAllPossibleInvasionStatesToCompletion(initialState InvasionState) []Inva
 }
 
 func OracleSolution(initalState InvasionState) InvasionState {
-	finalStates := RunAllPossibleInvasionStatesToCompletionGreedy(initalState)
+	return BestInvasionState(RunAllPossibleInvasionStatesToCompletionGreedy(initalState))
+}
+
+// Picks the ending with the fewest aliens left, then the most HP left, then the fewest commands used.
+// Ties go to whichever state comes first.
+func BestInvasionState(finalStates []InvasionState) InvasionState {
 	// Get the states with the smallest aliens left
 	smallestAliensLeft := lo.MinBy(finalStates, func(s1 InvasionState, s2 InvasionState) bool {
 		return s1.GetAliensLeft() < s2.GetAliensLeft()
@@ -156,6 +208,80 @@ func RunAllPossibleInvasionStatesToCompletionGreedy(initialState InvasionState)
 	return endingStates
 }
 
+// Canonical, hashable representation of an invasion state. Two states with the same multiset of
+// aliens and the same hp always have the same outcomes, regardless of the commands used to get there.
+type invasionStateKey string
+
+func (i InvasionState) canonicalKey() invasionStateKey {
+	var key strings.Builder
+	key.WriteString(strconv.Itoa(i.hpLeft))
+	for _, alien := range i.sortAliens().aliensLeft {
+		fmt.Fprintf(&key, "|%d,%d", alien.Hp, alien.Atk)
+	}
+	return invasionStateKey(key.String())
+}
+
+// Best reachable ending from some invasion state.
+type oracleOutcome struct {
+	aliensLeft   int
+	hpLeft       int
+	commandsUsed int    // Commands issued from the state until the invasion is over.
+	command      string // Next command to issue, empty if the state is already over.
+}
+
+// Fewest aliens left, then most hp left, then fewest commands used.
+func (o oracleOutcome) betterThan(other oracleOutcome) bool {
+	if o.aliensLeft != other.aliensLeft {
+		return o.aliensLeft < other.aliensLeft
+	}
+	if o.hpLeft != other.hpLeft {
+		return o.hpLeft > other.hpLeft
+	}
+	return o.commandsUsed < other.commandsUsed
+}
+
+// Dynamic programming version of the oracle. Rather than collecting every ending state, it memoizes the
+// best outcome per canonical state. Commands are explored in the same order as
+// RunAllPossibleInvasionStatesToCompletion and an outcome is only replaced by a strictly better one, so ties
+// resolve to the same ending BestInvasionState would pick from the exhaustive search.
+func MemoizedOracleSolution(initialState InvasionState) InvasionState {
+	memo := map[invasionStateKey]oracleOutcome{}
+
+	var solve func(state InvasionState) oracleOutcome
+	solve = func(state InvasionState) oracleOutcome {
+		if state.IsOver() {
+			return oracleOutcome{aliensLeft: state.GetAliensLeft(), hpLeft: state.GetHpLeft()}
+		}
+		key := state.canonicalKey()
+		if outcome, ok := memo[key]; ok {
+			return outcome
+		}
+		var best oracleOutcome
+		for idx, command := range []string{VOLLEY, FOCUSED_SHOT, FOCUSED_VOLLEY} {
+			next := solve(state.executeCommand(command))
+			candidate := oracleOutcome{
+				aliensLeft:   next.aliensLeft,
+				hpLeft:       next.hpLeft,
+				commandsUsed: next.commandsUsed + 1,
+				command:      command,
+			}
+			if idx == 0 || candidate.betterThan(best) {
+				best = candidate
+			}
+		}
+		memo[key] = best
+		return best
+	}
+	solve(initialState)
+
+	// Replay the memoized commands to rebuild the final state.
+	state := initialState
+	for !state.IsOver() {
+		state = state.executeCommand(memo[state.canonicalKey()].command)
+	}
+	return state
+}
+
 // The invasion is over if and only if all aliens are dead or the remaining hp is empty.
 func (i InvasionState) IsOver() bool {
 	return len(i.aliensLeft) == 0 || i.hpLeft <= 0
@@ -272,7 +398,6 @@ type Alien struct {
 
 // Creates an Alien with HP and ATK ranging from the upper and lower bounds
 func CreateAlien(hp int, atk int) Alien {
-	// Temporarily make the uuid generation dependent on the rng.
 	return Alien{Hp: hp, Atk: atk}
 }
 
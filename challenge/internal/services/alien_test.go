@@ -1,7 +1,9 @@
 package services_test
 
 import (
+	"generate_technical_challenge_2025/internal/database/models"
 	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/transactions"
 	"generate_technical_challenge_2025/internal/utils"
 	"slices"
 	"testing"
@@ -181,3 +183,164 @@ func TestAlgorithmCorrectness(t *testing.T) {
 	// Assert that the greedySol has the same amount of hp as the brute force.
 	assert.GreaterOrEqual(t, greedyBruteForceBestSolByHP.GetHpLeft(), bruteForceBestSolByHP.GetHpLeft())
 }
+
+func TestMemoizedOracleNeverWorseThanOracleSolution(t *testing.T) {
+	for range 20 {
+		sampleInvasionState := services.CreateInvasionState(services.GenerateAlienInvasion(RNG),
+			utils.GenerateRandomNumWithinRange(RNG, services.LOWER_HP_BOUND, services.UPPER_HP_BOUND))
+		oracleSol := services.OracleSolution(sampleInvasionState)
+		memoizedSol := services.MemoizedOracleSolution(sampleInvasionState)
+		// The greedy oracle prunes some volleys, so the memoized oracle can only do better.
+		assert.LessOrEqual(t, memoizedSol.GetAliensLeft(), oracleSol.GetAliensLeft())
+		if memoizedSol.GetAliensLeft() == oracleSol.GetAliensLeft() {
+			assert.GreaterOrEqual(t, memoizedSol.GetHpLeft(), oracleSol.GetHpLeft())
+		}
+	}
+}
+
+func TestMemoizedOracleAgreesWithBruteForce(t *testing.T) {
+	// Brute force is exponential, so keep the seeded waves small enough to enumerate.
+	rng := utils.CreateRNGFromHash(uuid.New())
+	for range 2000 {
+		aliens := []services.Alien{}
+		for range utils.GenerateRandomNumWithinRange(rng, 1, 7) {
+			aliens = append(aliens, services.CreateAlien(
+				utils.GenerateRandomNumWithinRange(rng, services.ALIEN_ATK_HP_SPD_LOWER, services.ALIEN_ATK_HP_SPD_UPPER),
+				utils.GenerateRandomNumWithinRange(rng, services.ALIEN_ATK_HP_SPD_LOWER, services.ALIEN_ATK_HP_SPD_UPPER)))
+		}
+		sampleInvasionState := services.CreateInvasionState(aliens, utils.GenerateRandomNumWithinRange(rng, 5, 25))
+		bruteforceSol := services.BestInvasionState(services.RunAllPossibleInvasionStatesToCompletion(sampleInvasionState))
+		memoizedSol := services.MemoizedOracleSolution(sampleInvasionState)
+		// Ties must be broken exactly the same way, down to the commands used.
+		assert.Equal(t, bruteforceSol.GetAliensLeft(), memoizedSol.GetAliensLeft())
+		assert.Equal(t, bruteforceSol.GetHpLeft(), memoizedSol.GetHpLeft())
+		assert.Equal(t, bruteforceSol.GetCommandsUsed(), memoizedSol.GetCommandsUsed())
+	}
+}
+
+func TestScoreMemberSubmissionPerWaveBreakdown(t *testing.T) {
+	memberID := uuid.New()
+	challenges := CHALLENGE_SERVICE_IMPL.GenerateUniqueAlienChallenge(memberID, services.CURRENT_CHALLENGE_VERSION)
+	submission := lo.MapEntries(challenges, func(challengeID uuid.UUID, state services.InvasionState) (uuid.UUID, services.UserChallengeSubmission) {
+		oracleSol := services.MemoizedOracleSolution(state)
+		return challengeID, services.UserChallengeSubmission{Hp: oracleSol.GetHpLeft(), Commands: oracleSol.GetCommandsUsed(), AliensLeft: oracleSol.GetAliensLeft()}
+	})
+
+	// A perfect submission is valid on every wave and costs no points.
+	answer := CHALLENGE_SERVICE_IMPL.ScoreMemberSubmission(memberID, services.CURRENT_CHALLENGE_VERSION, submission)
+	assert.True(t, answer.Valid)
+	assert.Equal(t, 0, answer.Score)
+	assert.Len(t, answer.Waves, services.NUM_WAVES)
+	for _, wave := range answer.Waves {
+		assert.True(t, wave.Valid)
+		assert.Equal(t, 0, wave.Score())
+		assert.NotNil(t, wave.FinalState)
+	}
+
+	// Lying about the remaining HP on one wave only invalidates that wave.
+	tamperedID := answer.Waves[0].ChallengeID
+	tampered := submission[tamperedID]
+	tampered.Hp++
+	submission[tamperedID] = tampered
+	answer = CHALLENGE_SERVICE_IMPL.ScoreMemberSubmission(memberID, services.CURRENT_CHALLENGE_VERSION, submission)
+	assert.False(t, answer.Valid)
+	assert.False(t, answer.Waves[0].Valid)
+	assert.Equal(t, answer.Waves[0].Reason, answer.Message)
+	for _, wave := range answer.Waves[1:] {
+		assert.True(t, wave.Valid)
+	}
+}
+
+// Keeps the last submission saved.
+type submissionRecorder struct {
+	transactions.ChallengeTransactions
+	submission *models.Submission
+}
+
+func (r *submissionRecorder) SaveAlienChallengeSolutionsForMember(score *models.Score, submission *models.Submission) error {
+	r.submission = submission
+	return nil
+}
+
+func TestSaveAlienSubmissionOnlyStoresTheMembersWaves(t *testing.T) {
+	recorder := &submissionRecorder{}
+	service := services.CreateChallengeService(LOGGER, recorder, services.NgrokLatencyConfig{}, true)
+	memberID := uuid.New()
+	challenges := service.GenerateUniqueAlienChallenge(memberID, services.CURRENT_CHALLENGE_VERSION)
+	submission := lo.MapValues(challenges, func(state services.InvasionState, _ uuid.UUID) services.UserChallengeSubmission {
+		oracleSol := services.MemoizedOracleSolution(state)
+		return services.UserChallengeSubmission{Hp: oracleSol.GetHpLeft(), Commands: oracleSol.GetCommandsUsed(), AliensLeft: oracleSol.GetAliensLeft()}
+	})
+	longID := lo.Keys(challenges)[0]
+	long := submission[longID]
+	long.Commands = slices.Repeat([]string{services.VOLLEY}, services.MAX_STORED_SUBMISSION_COMMANDS+1)
+	submission[longID] = long
+	unknownID := uuid.New()
+	submission[unknownID] = services.UserChallengeSubmission{Commands: []string{services.VOLLEY}}
+
+	answer := service.ScoreMemberSubmission(memberID, services.CURRENT_CHALLENGE_VERSION, submission)
+	assert.Len(t, answer.Waves, services.NUM_WAVES+1)
+	assert.NoError(t, service.SaveAlienSubmission(memberID, submission, answer))
+
+	waves := recorder.submission.Waves
+	assert.ElementsMatch(t, lo.Keys(challenges), lo.Map(waves, func(wave models.SubmissionWave, _ int) uuid.UUID { return wave.ChallengeID }))
+	for _, wave := range waves {
+		if wave.ChallengeID == longID {
+			assert.Len(t, wave.Commands, services.MAX_STORED_SUBMISSION_COMMANDS)
+		} else {
+			assert.Equal(t, submission[wave.ChallengeID].Commands, wave.Commands)
+		}
+	}
+}
+
+func TestReplayCommandsMatchesSimulator(t *testing.T) {
+	sampleInvasionState := services.CreateInvasionState(services.GenerateAlienInvasion(RNG), 100)
+	oracleSol := services.OracleSolution(sampleInvasionState)
+	steps, completed := services.ReplayCommands(sampleInvasionState, oracleSol.GetCommandsUsed())
+	assert.True(t, completed)
+	assert.Len(t, steps, oracleSol.GetNumberOfCommandsUsed()+1)
+	// The first step is the untouched starting state.
+	assert.Equal(t, sampleInvasionState.GetHpLeft(), steps[0].State.GetHpLeft())
+	assert.Empty(t, steps[0].HitAliens)
+	// Every step agrees with running the same prefix of commands through the simulator.
+	for idx, step := range steps[1:] {
+		prefix := services.RunCommandsToCompletion(sampleInvasionState, oracleSol.GetCommandsUsed()[:idx+1])
+		assert.Equal(t, prefix.GetHpLeft(), step.State.GetHpLeft())
+		assert.Equal(t, prefix.SurveyRemainingAlienInvasion(), step.State.SurveyRemainingAlienInvasion())
+		// Killed aliens are exactly the ones missing afterwards.
+		assert.Equal(t, steps[idx].State.GetAliensLeft()-len(step.KilledAliens), step.State.GetAliensLeft())
+		assert.Subset(t, step.HitAliens, step.KilledAliens)
+	}
+}
+
+func TestReplayCommandsStopsWhenInvasionIsOver(t *testing.T) {
+	exampleAlienInvasion := services.CreateInvasionState([]services.Alien{
+		services.CreateAlien(1, 2),
+	}, 100)
+	steps, completed := services.ReplayCommands(exampleAlienInvasion, []string{services.FOCUSED_SHOT, services.VOLLEY})
+	assert.False(t, completed)
+	assert.Len(t, steps, 2)
+	assert.Equal(t, []int{0}, steps[1].HitAliens)
+	assert.Equal(t, []int{0}, steps[1].KilledAliens)
+	assert.Equal(t, 100, steps[1].State.GetHpLeft())
+}
+
+func TestRevealAlienChallengeSolutionsOnceCohortCloses(t *testing.T) {
+	memberID := uuid.New()
+	types := []string{models.ALGORITHM_CHALLENGE_TYPE}
+	neverCloses := *models.CreateCohort("Always open", time.Time{}, time.Time{}, types, services.CURRENT_CHALLENGE_VERSION)
+	_, err := CHALLENGE_SERVICE_IMPL.RevealAlienChallengeSolutions(memberID, services.CURRENT_CHALLENGE_VERSION, neverCloses)
+	assert.ErrorIs(t, err, services.ErrCohortStillOpen)
+
+	open := *models.CreateCohort("Open", time.Now().Add(-time.Hour), time.Now().Add(time.Hour), types, services.CURRENT_CHALLENGE_VERSION)
+	_, err = CHALLENGE_SERVICE_IMPL.RevealAlienChallengeSolutions(memberID, services.CURRENT_CHALLENGE_VERSION, open)
+	assert.ErrorIs(t, err, services.ErrCohortStillOpen)
+
+	closed := *models.CreateCohort("Closed", time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour), types, services.CURRENT_CHALLENGE_VERSION)
+	solutions, err := CHALLENGE_SERVICE_IMPL.RevealAlienChallengeSolutions(memberID, services.CURRENT_CHALLENGE_VERSION, closed)
+	assert.NoError(t, err)
+	assert.Len(t, solutions, services.NUM_WAVES)
+	for challengeID, state := range CHALLENGE_SERVICE_IMPL.GenerateUniqueAlienChallenge(memberID, services.CURRENT_CHALLENGE_VERSION) {
+		assert.Equal(t, services.MemoizedOracleSolution(state).GetCommandsUsed(), solutions[challengeID].GetCommandsUsed())
+	}
+}
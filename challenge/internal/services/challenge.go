@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"generate_technical_challenge_2025/internal/database/models"
 	"generate_technical_challenge_2025/internal/transactions"
 	"generate_technical_challenge_2025/internal/utils"
 	"log/slog"
@@ -11,6 +13,7 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
+	"sort"
 	"sync"
 	"time"
 
@@ -20,12 +23,17 @@ import (
 )
 
 type ChallengeService interface {
-	GenerateUniqueAlienChallenge(id uuid.UUID) map[uuid.UUID]InvasionState
-	GenerateUniqueFrontendChallenge(id uuid.UUID) []DetailedAlien
-	ScoreMemberSubmission(memberID uuid.UUID, submission map[uuid.UUID]UserChallengeSubmission) OracleAnswer
-	GenerateUniqueNgrokChallenge(memberID uuid.UUID) NgrokChallenge
+	GenerateUniqueAlienChallenge(id uuid.UUID, version int) map[uuid.UUID]InvasionState
+	GenerateUniqueFrontendChallenge(id uuid.UUID, version int) []DetailedAlien
+	ScoreMemberSubmission(memberID uuid.UUID, version int, submission map[uuid.UUID]UserChallengeSubmission) OracleAnswer
+	SaveAlienSubmission(memberID uuid.UUID, submission map[uuid.UUID]UserChallengeSubmission, answer OracleAnswer) error
+	RevealAlienChallengeSolutions(memberID uuid.UUID, version int, cohort models.Cohort) (map[uuid.UUID]InvasionState, error)
+	GenerateUniqueNgrokChallenge(memberID uuid.UUID, version int) NgrokChallenge
 	GradeNgrokServer(ctx context.Context, url url.URL, requests NgrokChallenge) NgrokChallengeScore
 	HealthCheck(ctx context.Context, url url.URL) (bool, error)
+	SubmitNgrokJob(memberID uuid.UUID, version int, serverURL url.URL) (*models.NgrokJob, error)
+	GetNgrokJob(memberID uuid.UUID, jobID uuid.UUID) (*models.NgrokJob, bool, error)
+	StartNgrokWorkers(ctx context.Context, workers int, members MemberService) error
 }
 
 type UserChallengeSubmission struct {
@@ -35,55 +43,159 @@ type UserChallengeSubmission struct {
 }
 
 type OracleAnswer struct {
-	Score   int
-	Message string
-	Valid   bool
+	Score            int
+	Message          string
+	Valid            bool
+	Waves            []WaveResult // One result per challenge ID, sorted by challenge ID.
+	ChallengeVersion int          // Version of the generators the waves were graded against.
+}
+
+// Grading breakdown for a single wave of a member's submission.
+type WaveResult struct {
+	ChallengeID  uuid.UUID
+	Valid        bool
+	Reason       string         // optional, only set when Valid = false
+	FinalState   *InvasionState // The member's simulated final state, nil if their commands could not be run.
+	OracleState  *InvasionState // The optimal final state, nil if the wave does not belong to the member.
+	HpScore      int
+	AlienScore   int
+	CommandScore int
+}
+
+func (w WaveResult) Score() int {
+	return w.HpScore + w.AlienScore + w.CommandScore
 }
 
 type ChallengeServiceImpl struct {
 	logger       *slog.Logger
 	transactions transactions.ChallengeTransactions
 	customClient *http.Client
+	latency      NgrokLatencyConfig
+	stressRound  bool           // Whether ngrok challenges that have a stress round are graded with it.
+	ngrokJobs    chan uuid.UUID // IDs of queued ngrok grading jobs.
 }
 
+var ErrCohortStillOpen = errors.New("cohort has not closed yet")
+
 // ScoreMemberSubmission implements ChallengeService.
-func (c ChallengeServiceImpl) ScoreMemberSubmission(memberID uuid.UUID, submission map[uuid.UUID]UserChallengeSubmission) OracleAnswer {
-	challenges := c.GenerateUniqueAlienChallenge(memberID)
+func (c ChallengeServiceImpl) ScoreMemberSubmission(memberID uuid.UUID, version int, submission map[uuid.UUID]UserChallengeSubmission) OracleAnswer {
+	challenges := c.GenerateUniqueAlienChallenge(memberID, version)
+	// Grade every challenge ID that was either generated or submitted, in a stable order.
+	challengeIDs := mapset.NewSet(lo.Keys(challenges)...).Union(mapset.NewSet(lo.Keys(submission)...)).ToSlice()
+	sort.Slice(challengeIDs, func(i, j int) bool {
+		return challengeIDs[i].String() < challengeIDs[j].String()
+	})
+	waves := lo.Map(challengeIDs, func(challengeID uuid.UUID, _ int) WaveResult {
+		return scoreWave(challengeID, challenges, submission)
+	})
+	answer := OracleAnswer{Message: "Submission successfully recorded.", Valid: true, Waves: waves, ChallengeVersion: version}
 	// First check is that keys match.
 	oracleChallengeKeys := mapset.NewSet(lo.Keys(challenges)...)
 	memberChallengeKeys := mapset.NewSet(lo.Keys(submission)...)
 	if !oracleChallengeKeys.Equal(memberChallengeKeys) {
-		return OracleAnswer{Message: "Challenge IDs do not match.", Valid: false}
+		answer.Message = "Challenge IDs do not match."
+		answer.Valid = false
+		return answer
 	}
-	aggregatedAnswer := 0
-	// If they do match, now run each simulation and check to see if it agrees with the oracles solution.
-	for challengeID, submission := range submission {
-		// Next check if the that each string in the commands field must match the commands in the spec.
-		invalidCommands := lo.Filter(submission.Commands, func(command string, _ int) bool {
-			return command != VOLLEY && command != FOCUSED_SHOT && command != FOCUSED_VOLLEY
-		})
-		if len(invalidCommands) > 0 {
-			return OracleAnswer{Message: "Invalid commands detected for this challenge id: " + challengeID.String(), Valid: false}
+	for _, wave := range waves {
+		if !wave.Valid {
+			answer.Message = wave.Reason
+			answer.Valid = false
+			return answer
+		}
+		answer.Score += wave.Score()
+	}
+	return answer
+}
+
+// Runs a single wave of the member's submission and checks to see if it agrees with the oracles solution.
+func scoreWave(challengeID uuid.UUID, challenges map[uuid.UUID]InvasionState, submissions map[uuid.UUID]UserChallengeSubmission) WaveResult {
+	state, generated := challenges[challengeID]
+	if !generated {
+		return WaveResult{ChallengeID: challengeID, Reason: "Challenge ID does not belong to this member: " + challengeID.String()}
+	}
+	// Run oracles algorithm
+	finalOracleState := MemoizedOracleSolution(state)
+	submission, submitted := submissions[challengeID]
+	if !submitted {
+		return WaveResult{ChallengeID: challengeID, OracleState: &finalOracleState, Reason: "No submission given for this challenge id: " + challengeID.String()}
+	}
+	// Next check if the that each string in the commands field must match the commands in the spec.
+	invalidCommands := lo.Filter(submission.Commands, func(command string, _ int) bool {
+		return command != VOLLEY && command != FOCUSED_SHOT && command != FOCUSED_VOLLEY
+	})
+	if len(invalidCommands) > 0 {
+		return WaveResult{ChallengeID: challengeID, OracleState: &finalOracleState, Reason: "Invalid commands detected for this challenge id: " + challengeID.String()}
+	}
+	finalUserState := RunCommandsToCompletion(state, submission.Commands)
+	if finalUserState == nil {
+		return WaveResult{ChallengeID: challengeID, OracleState: &finalOracleState, Reason: "Commands given resulted in a state ending prematurely before all commands could be executed."}
+	}
+	// Check to see if the final HP and final remaining aliens match
+	if finalUserState.GetAliensLeft() != submission.AliensLeft || finalUserState.GetHpLeft() != submission.Hp || finalUserState.GetNumberOfCommandsUsed() != len(submission.Commands) {
+		return WaveResult{ChallengeID: challengeID, FinalState: finalUserState, OracleState: &finalOracleState, Reason: "Submission HP, aliens, or commands left do not match for this challenge id: " + challengeID.String()}
+	}
+	// Take the absolute difference between oracle solution user solution
+	return WaveResult{
+		ChallengeID:  challengeID,
+		Valid:        true,
+		FinalState:   finalUserState,
+		OracleState:  &finalOracleState,
+		HpScore:      int(math.Abs(float64(finalOracleState.GetHpLeft()) - float64(finalUserState.GetHpLeft()))),
+		AlienScore:   int(math.Abs(float64(finalOracleState.GetAliensLeft()) - float64(finalUserState.GetAliensLeft()))),
+		CommandScore: int(math.Abs(float64(finalOracleState.GetNumberOfCommandsUsed()) - float64(finalUserState.GetNumberOfCommandsUsed()))),
+	}
+}
+
+// RevealAlienChallengeSolutions implements ChallengeService.
+// Returns the oracle's final state for each of the member's waves, but only once the member's cohort has closed, so
+// that the solutions can never be submitted back. Cohorts that never close never get solutions.
+func (c ChallengeServiceImpl) RevealAlienChallengeSolutions(memberID uuid.UUID, version int, cohort models.Cohort) (map[uuid.UUID]InvasionState, error) {
+	if cohort.ClosesAt.IsZero() || time.Now().Before(cohort.ClosesAt) {
+		return nil, ErrCohortStillOpen
+	}
+	return lo.MapValues(c.GenerateUniqueAlienChallenge(memberID, version), func(state InvasionState, _ uuid.UUID) InvasionState {
+		return MemoizedOracleSolution(state)
+	}), nil
+}
+
+// Most commands stored for a wave. Every wave is over well before this, so longer submissions are never valid.
+const MAX_STORED_SUBMISSION_COMMANDS = 1000
+
+// SaveAlienSubmission implements ChallengeService.
+// Records the score along with what the member sent for each of their waves, so interviewers can replay it. Waves
+// with challenge IDs that were never generated for the member are left out, and commands are cut off at
+// MAX_STORED_SUBMISSION_COMMANDS.
+func (c ChallengeServiceImpl) SaveAlienSubmission(memberID uuid.UUID, submission map[uuid.UUID]UserChallengeSubmission, answer OracleAnswer) error {
+	scoreValue := models.INVALID_SCORE
+	if answer.Valid {
+		scoreValue = answer.Score
+	}
+	score := models.CreateScore(memberID, models.ALGORITHM_CHALLENGE_TYPE, scoreValue, answer.Valid, answer.ChallengeVersion)
+	challenges := c.GenerateUniqueAlienChallenge(memberID, answer.ChallengeVersion)
+	memberWaves := lo.Filter(answer.Waves, func(wave WaveResult, _ int) bool {
+		_, generated := challenges[wave.ChallengeID]
+		return generated
+	})
+	waves := lo.Map(memberWaves, func(wave WaveResult, _ int) models.SubmissionWave {
+		record := models.CreateSubmissionWave(wave.ChallengeID, wave.Valid, wave.Reason)
+		if userSubmission, ok := submission[wave.ChallengeID]; ok {
+			record.Commands = userSubmission.Commands[:min(len(userSubmission.Commands), MAX_STORED_SUBMISSION_COMMANDS)]
+			record.ClaimedHp = lo.ToPtr(userSubmission.Hp)
+			record.ClaimedAliens = lo.ToPtr(userSubmission.AliensLeft)
 		}
-		state := challenges[challengeID]
-		finalUserState := RunCommandsToCompletion(state, submission.Commands)
-		if finalUserState == nil {
-			return OracleAnswer{Message: "Commands given resulted in a state ending prematurely before all commands could be executed.", Valid: false}
+		if wave.FinalState != nil {
+			record.SimulatedHp = lo.ToPtr(wave.FinalState.GetHpLeft())
+			record.SimulatedAliens = lo.ToPtr(wave.FinalState.GetAliensLeft())
 		}
-		// Check to see if the final HP and final remaining aliens match
-		if finalUserState.GetAliensLeft() != submission.AliensLeft || finalUserState.GetHpLeft() != submission.Hp || finalUserState.GetNumberOfCommandsUsed() != len(submission.Commands) {
-			return OracleAnswer{Message: "Submission HP, aliens, or commands left do not match for this challenge id: " + challengeID.String(), Valid: false}
+		if wave.OracleState != nil {
+			record.OracleHp = lo.ToPtr(wave.OracleState.GetHpLeft())
+			record.OracleAliens = lo.ToPtr(wave.OracleState.GetAliensLeft())
+			record.OracleCommands = wave.OracleState.GetCommandsUsed()
 		}
-		// Run oracles algorithm
-		finalOracleState := OracleSolution(state)
-		// Take the absolute difference between oracle solution user solution
-		//
-		hpScore := int(math.Abs(float64(finalOracleState.GetHpLeft()) - float64(finalUserState.GetHpLeft())))
-		alienScore := int(math.Abs(float64(finalOracleState.GetAliensLeft()) - float64(finalUserState.GetAliensLeft())))
-		commandScore := int(math.Abs(float64(finalOracleState.GetNumberOfCommandsUsed()) - float64(finalUserState.GetNumberOfCommandsUsed())))
-		aggregatedAnswer += hpScore + alienScore + commandScore
-	}
-	return OracleAnswer{Message: "Submission successfully recorded.", Score: aggregatedAnswer, Valid: true}
+		return record
+	})
+	return c.transactions.SaveAlienChallengeSolutionsForMember(score, models.CreateSubmission(score.ID, memberID, waves))
 }
 
 const (
@@ -104,6 +216,7 @@ const (
 	NGROK_FILTER_HP_POINTS       = 15
 	VERBOSE                      = false
 	NGROK_PATH                   = "/api/aliens"
+	NGROK_DIFF_SAMPLE_SIZE       = 5
 )
 
 var alienTypes = []AlienType{
@@ -113,33 +226,24 @@ var alienTypes = []AlienType{
 }
 
 // GenerateUniqueFrontendChallenge implements ChallengeService.
-func (c ChallengeServiceImpl) GenerateUniqueFrontendChallenge(id uuid.UUID) []DetailedAlien {
-	rng := utils.CreateRNGFromHash(id)
-	numAliens := utils.GenerateRandomNumWithinRange(rng, LOWER_DETAILED_ALIEN_AMOUNT, UPPER_DETAILED_ALIEN_AMOUNT)
-
-	aliens := []DetailedAlien{}
-	for idx := range numAliens {
-		alien := GenerateDetailedAlien(rng, id, idx)
-		aliens = append(aliens, alien)
-	}
-
-	return aliens
+func (c ChallengeServiceImpl) GenerateUniqueFrontendChallenge(id uuid.UUID, version int) []DetailedAlien {
+	return c.challengeGenerators(version).Frontend(id)
 }
 
 // GenerateUniqueAlienChallenge implements ChallengeService.
-func (c ChallengeServiceImpl) GenerateUniqueAlienChallenge(id uuid.UUID) map[uuid.UUID]InvasionState {
-	rng := utils.CreateRNGFromHash(id)
-	maps := map[uuid.UUID]InvasionState{}
-	uuid.SetRand(rng)
-	for range NUM_WAVES {
-		aliens := GenerateAlienInvasion(rng)
-		hp := utils.GenerateRandomNumWithinRange(rng, LOWER_HP_BOUND, UPPER_HP_BOUND)
-		invasionState := CreateInvasionState(aliens, hp)
-		challengeUUID := uuid.New()
-		maps[challengeUUID] = invasionState
+func (c ChallengeServiceImpl) GenerateUniqueAlienChallenge(id uuid.UUID, version int) map[uuid.UUID]InvasionState {
+	return c.challengeGenerators(version).Alien(id)
+}
+
+// Members should only ever have versions that were registered, but fall back to the current generators
+// rather than failing the request if one does not.
+func (c ChallengeServiceImpl) challengeGenerators(version int) ChallengeGenerators {
+	generators, ok := GetChallengeGenerators(version)
+	if !ok {
+		c.logger.Error("Unknown challenge version, using the current version instead.", slog.Int("version", version))
+		generators, _ = GetChallengeGenerators(CURRENT_CHALLENGE_VERSION)
 	}
-	uuid.SetRand(nil)
-	return maps
+	return generators
 }
 
 // SolveChallenge implements ChallengeService.
@@ -167,16 +271,19 @@ func (c ChallengeServiceImpl) SolveAlienChallenge(state InvasionState) InvasionS
 	return idealCandidate
 }
 
-func CreateChallengeService(logger *slog.Logger, transactions transactions.ChallengeTransactions) ChallengeService {
+// Without stressRound, the stress round is left out of every ngrok challenge, see NgrokStressRequest.
+func CreateChallengeService(logger *slog.Logger, transactions transactions.ChallengeTransactions, latency NgrokLatencyConfig, stressRound bool) ChallengeService {
 	client := &http.Client{
 		Timeout: 15 * time.Second,
 		Transport: &http.Transport{
-			MaxIdleConnsPerHost: 7,
+			// Enough for every request of the stress round to reuse a connection.
+			MaxIdleConnsPerHost: 2 * NGROK_STRESS_BATCHES,
 			IdleConnTimeout:     30 * time.Second,
 		},
 	}
 	return ChallengeServiceImpl{
-		logger: logger, transactions: transactions, customClient: client,
+		logger: logger, transactions: transactions, customClient: client, latency: latency, stressRound: stressRound,
+		ngrokJobs: make(chan uuid.UUID, NGROK_JOB_QUEUE_SIZE),
 	}
 }
 
@@ -194,11 +301,10 @@ func (c ChallengeServiceImpl) GradeNgrokServer(ctx context.Context, url url.URL,
 		return acc + req.GetTotalPossiblePoints()
 	}, 0)
 
-	totalScore := 0
-
 	var deleteRequest NgrokRequest
 	var postRequest NgrokRequest
 	var getRequests []NgrokRequest
+	var mutatingRequests []NgrokRequest
 
 	for _, request := range requests.Requests {
 		switch req := request.(type) {
@@ -206,88 +312,107 @@ func (c ChallengeServiceImpl) GradeNgrokServer(ctx context.Context, url url.URL,
 			deleteRequest = req
 		case NgrokPostRequest:
 			postRequest = req
-		case NgrokGetRequest:
+		case NgrokGetRequest, NgrokGetAlienRequest, NgrokMalformedQueryRequest:
 			getRequests = append(getRequests, req)
+		case NgrokInvalidPostRequest, NgrokPatchRequest, NgrokDeleteAlienRequest, NgrokStressRequest:
+			mutatingRequests = append(mutatingRequests, req)
 		}
 	}
 
 	baseURL := url.String()
+	results := []NgrokRequestResult{}
 
 	// 1. Clean up any old data.
 	if deleteRequest != nil {
-		sendDeleteRequest(ctx, deleteRequest, c.customClient, baseURL)
+		results = append(results, sendDeleteRequest(ctx, deleteRequest, c.customClient, baseURL))
 		time.Sleep(10 * time.Millisecond)
 	}
 
 	// 2) Populate data.
 	if postRequest != nil {
-		points, err := postRequest.Execute(ctx, c.customClient, baseURL)
-		if err != nil {
+		result := postRequest.Execute(ctx, c.customClient, baseURL)
+		results = append(results, result)
+		if result.Err != nil {
 
 			if VERBOSE {
-				fmt.Printf("POST request failed: %s\n", err.Error())
+				fmt.Printf("POST request failed: %s\n", result.Err.Error())
 			}
 
 			return NgrokChallengeScore{
-				Valid:  false,
-				Reason: fmt.Sprintf("POST request failed - %s", err.Error()),
-			}
-		} else {
-			// no error executing, add their points.
-			totalScore += points
-
-			if VERBOSE {
-				fmt.Printf("POST request succeeded (+%d points out of %d total points)\n", points, postRequest.GetTotalPossiblePoints())
+				Valid:   false,
+				Reason:  fmt.Sprintf("POST request failed - %s", result.Err.Error()),
+				Results: results,
 			}
+		}
 
+		if VERBOSE {
+			fmt.Printf("POST request succeeded (+%d points out of %d total points)\n", result.PointsEarned, result.PointsPossible)
 		}
+
 		time.Sleep(50 * time.Millisecond)
 	}
 
+	// 3) Make GET requests. Each goroutine only writes to its own slot, so no locking is needed.
+	getResults := make([]NgrokRequestResult, len(getRequests))
 	wg := sync.WaitGroup{}
 	wg.Add(len(getRequests))
-	// 3) Make GET requests.
-	for _, getRequest := range getRequests {
+	for idx, getRequest := range getRequests {
 
 		if VERBOSE {
 			fmt.Printf("Request: %s\n", getRequest.GetName())
 		}
 
-		go func(getRequest NgrokRequest) {
+		go func(idx int, getRequest NgrokRequest) {
 			defer wg.Done()
-			possibleAdjustedPoints, err := getRequest.Execute(ctx, c.customClient, baseURL)
-			if err != nil {
-				if VERBOSE {
-					fmt.Printf("GET request failed: %s\n", err.Error())
-				}
-			} else {
-				totalScore += possibleAdjustedPoints
-
-				if VERBOSE {
+			result := getRequest.Execute(ctx, c.customClient, baseURL)
+			getResults[idx] = result
+			if VERBOSE {
+				if result.Err != nil {
+					fmt.Printf("GET request failed: %s\n", result.Err.Error())
+				} else {
 					fmt.Printf("GET request succeeded (+%d points out of %d total points)\n",
-						possibleAdjustedPoints,
-						getRequest.GetTotalPossiblePoints())
+						result.PointsEarned,
+						result.PointsPossible)
 				}
 			}
-		}(getRequest)
+		}(idx, getRequest)
 
 		time.Sleep(10 * time.Millisecond)
 	}
 
 	wg.Wait()
+	results = append(results, getResults...)
+
+	// Measure latency while the posted aliens are still there.
+	latency := c.measureNgrokLatency(ctx, baseURL, requests.Version, getRequests, getResults)
+	if VERBOSE {
+		fmt.Printf("Latency p50=%s p95=%s (%+d points)\n", latency.P50, latency.P95, latency.Points)
+	}
+
+	// 4) Change individual aliens, one at a time since every GET above expects the posted aliens. The stress
+	// round goes last, since it starts over from no aliens.
+	for _, mutatingRequest := range mutatingRequests {
+		results = append(results, mutatingRequest.Execute(ctx, c.customClient, baseURL))
+	}
+
+	totalScore := lo.SumBy(results, func(result NgrokRequestResult) int {
+		return result.PointsEarned
+	})
 	// Finished sending all requests without returning early, so their
-	// submission must be valid.
+	// submission must be valid. A latency bonus can make up for lost points, but never go below 0.
 	return NgrokChallengeScore{
-		Valid: true,
-		Score: totalPossiblePoints - totalScore,
+		Valid:   true,
+		Score:   max(totalPossiblePoints-totalScore-latency.Points, 0),
+		Results: results,
+		Latency: latency,
 	}
 }
 
-func sendDeleteRequest(ctx context.Context, deleteRequest NgrokRequest, client *http.Client, baseURL string) {
-	_, err := deleteRequest.Execute(ctx, client, baseURL)
-	if err != nil {
+func sendDeleteRequest(ctx context.Context, deleteRequest NgrokRequest, client *http.Client, baseURL string) NgrokRequestResult {
+	result := deleteRequest.Execute(ctx, client, baseURL)
+	if result.Err != nil {
 		if VERBOSE {
-			fmt.Printf("DELETE request failed: %s\n", err.Error())
+			fmt.Printf("DELETE request failed: %s\n", result.Err.Error())
 		}
 
 		// Don't fail grading if DELETE fails, because it might be the first run.
@@ -296,6 +421,7 @@ func sendDeleteRequest(ctx context.Context, deleteRequest NgrokRequest, client *
 			fmt.Println("DELETE request succeeded")
 		}
 	}
+	return result
 }
 
 // An NgrokChallenge consists of:
@@ -312,21 +438,33 @@ func sendDeleteRequest(ctx context.Context, deleteRequest NgrokRequest, client *
 //     - hp_lte=
 //     - hp_gte=
 //  5. A GET request with contradicting filters (e.g. atk_lte=3 and atk_gte=5)
-func (c ChallengeServiceImpl) GenerateUniqueNgrokChallenge(memberID uuid.UUID) NgrokChallenge {
-	rng := utils.CreateRNGFromHash(memberID)
-	aliens := GenerateNgrokAliens(rng, memberID)
-
-	// Initial required requests.
-	requests := []NgrokRequest{
-		generateDeleteRequest(),
-		generatePostRequest(aliens),
-		generateGetAllRequest(aliens),
+//
+// Since version 4, every posted alien has a unique ID (see GenerateUniqueNgrokAliens), and the GET requests also
+// include (see GenerateCompoundFilterTests):
+//  6. GET requests combining several randomized filters, graded with EvaluateAlienQuery.
+//  7. A GET request with an unknown parameter, which should be ignored.
+//  8. GET requests with a repeated or malformed parameter, which should be rejected with a 400.
+//
+// Followed by (see GenerateExtendedTests and GenerateValidationTests):
+//  9. A GET request for a single alien by ID.
+//  10. A GET request for a page of aliens with limit= and offset=.
+//  11. A GET request sorted by a random field and order (e.g. sort=spd&order=desc).
+//  12. A GET request with a non-numeric filter, which should be rejected with a 400.
+//  13. POST requests with invalid aliens, which should be rejected with a 4xx and leave the aliens unchanged.
+//  14. A PATCH request for a single alien, then a DELETE request for a different alien.
+//
+// Since version 5, followed by a stress round (see NgrokStressRequest), unless the service was created without it:
+//  15. Concurrent POST requests of disjoint batches, interleaved with GET requests, then a repeated DELETE.
+func (c ChallengeServiceImpl) GenerateUniqueNgrokChallenge(memberID uuid.UUID, version int) NgrokChallenge {
+	challenge := c.challengeGenerators(version).Ngrok(memberID)
+	challenge.Version = version
+	if !c.stressRound {
+		challenge.Requests = lo.Reject(challenge.Requests, func(request NgrokRequest, _ int) bool {
+			_, stress := request.(NgrokStressRequest)
+			return stress
+		})
 	}
-
-	// Randomized filter requests.
-	requests = append(requests, GenerateRandomFilterTests(rng, slices.Clone(aliens))...)
-
-	return NgrokChallenge{Requests: requests}
+	return challenge
 }
 
 func generateDeleteRequest() NgrokRequest {
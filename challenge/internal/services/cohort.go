@@ -0,0 +1,205 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/transactions"
+	"generate_technical_challenge_2025/internal/utils"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+type CohortService interface {
+	SyncCohorts(cohorts []CohortConfig, defaultCohort string) error
+	GetCohorts() ([]models.Cohort, error)
+	GetCohortByName(name string) (*models.Cohort, bool, error)
+	GetRegistrationCohort(name string) (*models.Cohort, error)
+}
+
+// A cohort as configured by the team running it, see models.Cohort.
+type CohortConfig struct {
+	Name     string    `json:"name"`
+	OpensAt  time.Time `json:"opensAt"`
+	ClosesAt time.Time `json:"closesAt"`
+	// Left out to score members on every registered challenge.
+	ChallengeTypes []string `json:"challengeTypes"`
+	// Left out to use CURRENT_CHALLENGE_VERSION when the cohort is created. A cohort that already exists keeps its
+	// version, so bumping CURRENT_CHALLENGE_VERSION never changes the challenge partway through a cohort.
+	ChallengeVersion int `json:"challengeVersion"`
+}
+
+type CohortServiceImpl struct {
+	logger         *slog.Logger
+	transactions   transactions.CohortTransactions
+	challengeTypes []string // Every registered challenge type, see ChallengeRegistry.Types.
+}
+
+var (
+	ErrCohortNotFound       = errors.New("cohort not found")
+	ErrCohortNotOpen        = errors.New("cohort is not open")
+	ErrCohortRequired       = errors.New("several cohorts are open, one must be chosen")
+	ErrChallengeNotInCohort = errors.New("challenge is not part of the cohort")
+)
+
+func CreateCohortService(logger *slog.Logger, transactions transactions.CohortTransactions, challengeTypes []string) CohortService {
+	return CohortServiceImpl{logger: logger, transactions: transactions, challengeTypes: challengeTypes}
+}
+
+// Cohorts from the COHORTS_FILE, or only the default cohort, always open, when it is not set.
+func CreateCohortConfigs(env utils.EnvConfig) ([]CohortConfig, error) {
+	if env.COHORTS_FILE == "" {
+		return []CohortConfig{{Name: env.DEFAULT_COHORT}}, nil
+	}
+	return LoadCohortConfigs(env.COHORTS_FILE)
+}
+
+// Reads a JSON array of CohortConfig from the file.
+func LoadCohortConfigs(path string) ([]CohortConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cohorts []CohortConfig
+	if err := json.Unmarshal(content, &cohorts); err != nil {
+		return nil, fmt.Errorf("parsing cohorts in %s: %w", path, err)
+	}
+	return cohorts, nil
+}
+
+// SyncCohorts implements CohortService.
+// Creates or updates every configured cohort, leaving cohorts that are no longer configured untouched. An existing
+// cohort only moves to another challenge version when its config pins one. Members that registered before cohorts
+// existed are moved into the default cohort, which must exist afterwards.
+func (s CohortServiceImpl) SyncCohorts(cohorts []CohortConfig, defaultCohort string) error {
+	// Nothing is saved unless every cohort is valid.
+	validated := make([]*models.Cohort, len(cohorts))
+	names := map[string]bool{}
+	for idx, config := range cohorts {
+		if names[config.Name] {
+			return fmt.Errorf("cohort %q is configured twice", config.Name)
+		}
+		names[config.Name] = true
+		cohort, err := s.validate(config)
+		if err != nil {
+			return err
+		}
+		validated[idx] = cohort
+	}
+	for idx, cohort := range validated {
+		if cohorts[idx].ChallengeVersion == 0 {
+			existing, exists, err := s.transactions.GetCohortByName(cohort.Name)
+			if err != nil {
+				return err
+			}
+			if exists {
+				cohort.ChallengeVersion = existing.ChallengeVersion
+			}
+		}
+		if err := s.transactions.UpsertCohort(cohort); err != nil {
+			return err
+		}
+	}
+
+	cohort, exists, err := s.transactions.GetCohortByName(defaultCohort)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("default cohort %q is not configured", defaultCohort)
+	}
+	moved, err := s.transactions.AssignMembersWithoutCohort(cohort.ID)
+	if err != nil {
+		return err
+	}
+	if moved > 0 {
+		s.logger.Info("Moved members without a cohort into the default cohort", "cohort", defaultCohort, "members", moved)
+	}
+	return nil
+}
+
+// Fills in the defaults of the config, and checks that it only uses registered challenges and generators.
+func (s CohortServiceImpl) validate(config CohortConfig) (*models.Cohort, error) {
+	if config.Name == "" {
+		return nil, errors.New("cohort name is required")
+	}
+	if !config.ClosesAt.IsZero() && !config.ClosesAt.After(config.OpensAt) {
+		return nil, fmt.Errorf("cohort %q closes before it opens", config.Name)
+	}
+	challengeTypes := config.ChallengeTypes
+	if len(challengeTypes) == 0 {
+		challengeTypes = s.challengeTypes
+	}
+	if unknown, _ := lo.Difference(challengeTypes, s.challengeTypes); len(unknown) > 0 {
+		return nil, fmt.Errorf("cohort %q: %w: %v", config.Name, ErrUnknownChallengeType, unknown)
+	}
+	version := config.ChallengeVersion
+	if version == 0 {
+		version = CURRENT_CHALLENGE_VERSION
+	}
+	if _, ok := GetChallengeGenerators(version); !ok {
+		return nil, fmt.Errorf("cohort %q: unknown challenge version %d", config.Name, version)
+	}
+	return models.CreateCohort(config.Name, config.OpensAt, config.ClosesAt, challengeTypes, version), nil
+}
+
+// GetCohorts implements CohortService.
+func (s CohortServiceImpl) GetCohorts() ([]models.Cohort, error) {
+	return s.transactions.GetCohorts()
+}
+
+// GetCohortByName implements CohortService.
+// Returns the cohort and whether it exists.
+func (s CohortServiceImpl) GetCohortByName(name string) (*models.Cohort, bool, error) {
+	return s.transactions.GetCohortByName(name)
+}
+
+// GetRegistrationCohort implements CohortService.
+// Returns the named cohort if it is open, or the only open cohort when no name is given.
+func (s CohortServiceImpl) GetRegistrationCohort(name string) (*models.Cohort, error) {
+	if name != "" {
+		cohort, exists, err := s.transactions.GetCohortByName(name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrCohortNotFound
+		}
+		return cohort, CheckCohortOpen(*cohort)
+	}
+
+	cohorts, err := s.transactions.GetCohorts()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	open := slices.DeleteFunc(cohorts, func(cohort models.Cohort) bool { return !cohort.IsOpen(now) })
+	switch len(open) {
+	case 0:
+		return nil, ErrCohortNotOpen
+	case 1:
+		return &open[0], nil
+	}
+	return nil, ErrCohortRequired
+}
+
+// Checks that the cohort is open right now.
+func CheckCohortOpen(cohort models.Cohort) error {
+	if !cohort.IsOpen(time.Now()) {
+		return ErrCohortNotOpen
+	}
+	return nil
+}
+
+// Checks that members of the cohort are scored on the challenge, and that the cohort is open right now.
+func CheckChallengeAccess(cohort models.Cohort, challengeType string) error {
+	if !cohort.HasChallenge(challengeType) {
+		return ErrChallengeNotInCohort
+	}
+	return CheckCohortOpen(cohort)
+}
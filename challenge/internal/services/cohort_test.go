@@ -0,0 +1,115 @@
+package services_test
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/transactions"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckChallengeAccess(t *testing.T) {
+	now := time.Now()
+	open := *models.CreateCohort("Fall", now.Add(-time.Hour), now.Add(time.Hour), []string{models.ALGORITHM_CHALLENGE_TYPE}, 1)
+	assert.NoError(t, services.CheckChallengeAccess(open, models.ALGORITHM_CHALLENGE_TYPE))
+	assert.ErrorIs(t, services.CheckChallengeAccess(open, models.NGROK_CHALLENGE_TYPE), services.ErrChallengeNotInCohort)
+
+	closed := open
+	closed.ClosesAt = now.Add(-time.Minute)
+	assert.ErrorIs(t, services.CheckChallengeAccess(closed, models.ALGORITHM_CHALLENGE_TYPE), services.ErrCohortNotOpen)
+	notYetOpen := open
+	notYetOpen.OpensAt = now.Add(time.Minute)
+	assert.ErrorIs(t, services.CheckCohortOpen(notYetOpen), services.ErrCohortNotOpen)
+
+	// A cohort without a closing time stays open.
+	neverCloses := open
+	neverCloses.ClosesAt = time.Time{}
+	assert.True(t, neverCloses.IsOpen(now.AddDate(100, 0, 0)))
+}
+
+func TestLoadCohortConfigs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cohorts.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"name": "Fall 2025", "opensAt": "2025-09-01T00:00:00Z", "closesAt": "2025-10-01T00:00:00Z", "challengeTypes": ["algorithm"], "challengeVersion": 3},
+		{"name": "Spring 2026", "opensAt": "2026-01-15T00:00:00Z"}
+	]`), 0o600))
+
+	cohorts, err := services.LoadCohortConfigs(path)
+	require.NoError(t, err)
+	assert.Equal(t, []services.CohortConfig{
+		{
+			Name:             "Fall 2025",
+			OpensAt:          time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+			ClosesAt:         time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			ChallengeTypes:   []string{models.ALGORITHM_CHALLENGE_TYPE},
+			ChallengeVersion: 3,
+		},
+		{Name: "Spring 2026", OpensAt: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+	}, cohorts)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "Fall 2025"}`), 0o600))
+	_, err = services.LoadCohortConfigs(path)
+	assert.Error(t, err)
+}
+
+func TestSyncCohortsRejectsInvalidConfigs(t *testing.T) {
+	// Every config is rejected before the database is used.
+	service := services.CreateCohortService(LOGGER, transactions.CreateCohortTransactions(LOGGER, nil),
+		CHALLENGES.Types())
+	now := time.Now()
+	for name, cohorts := range map[string][]services.CohortConfig{
+		"missing name":    {{OpensAt: now}},
+		"closes too soon": {{Name: "Fall", OpensAt: now, ClosesAt: now.Add(-time.Hour)}},
+		"unknown type":    {{Name: "Fall", ChallengeTypes: []string{"design"}}},
+		"unknown version": {{Name: "Fall", ChallengeVersion: services.CURRENT_CHALLENGE_VERSION + 1}},
+		"skipped version": {{Name: "Fall", ChallengeVersion: 2}},
+		"invalid later":   {{Name: "Fall"}, {Name: "Spring", ChallengeVersion: -1}},
+		"duplicate name":  {{Name: "Fall"}, {Name: "Fall"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, service.SyncCohorts(cohorts, "Fall"))
+		})
+	}
+}
+
+// In memory stand in for the cohort table.
+type fakeCohortTransactions struct {
+	transactions.CohortTransactions
+	cohorts map[string]models.Cohort
+}
+
+func (f *fakeCohortTransactions) UpsertCohort(cohort *models.Cohort) error {
+	f.cohorts[cohort.Name] = *cohort
+	return nil
+}
+
+func (f *fakeCohortTransactions) GetCohortByName(name string) (*models.Cohort, bool, error) {
+	cohort, ok := f.cohorts[name]
+	return &cohort, ok, nil
+}
+
+func (f *fakeCohortTransactions) AssignMembersWithoutCohort(uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+func TestSyncCohortsKeepsTheVersionOfExistingCohorts(t *testing.T) {
+	fake := &fakeCohortTransactions{cohorts: map[string]models.Cohort{
+		"Fall": *models.CreateCohort("Fall", time.Now(), time.Time{}, []string{models.ALGORITHM_CHALLENGE_TYPE}, 1),
+	}}
+	service := services.CreateCohortService(LOGGER, fake, CHALLENGES.Types())
+
+	// A running cohort stays on its version, even though a newer one is current.
+	require.NoError(t, service.SyncCohorts([]services.CohortConfig{{Name: "Fall"}, {Name: "Spring"}}, "Fall"))
+	assert.Equal(t, 1, fake.cohorts["Fall"].ChallengeVersion)
+	assert.Equal(t, services.CURRENT_CHALLENGE_VERSION, fake.cohorts["Spring"].ChallengeVersion)
+
+	// Pinning a version moves it.
+	require.NoError(t, service.SyncCohorts([]services.CohortConfig{{Name: "Fall", ChallengeVersion: 4}}, "Fall"))
+	assert.Equal(t, 4, fake.cohorts["Fall"].ChallengeVersion)
+}
@@ -67,12 +67,13 @@ func TestGenerateDetailedAlien(t *testing.T) {
 var (
 	LOGGER                 = slog.New(slog.Default().Handler())
 	CHALLENGE_SERVICE_IMPL = services.CreateChallengeService(LOGGER,
-		transactions.CreateChallengeTransactions(LOGGER, nil)) // nil DB for testing.
+		transactions.CreateChallengeTransactions(LOGGER, nil), services.NgrokLatencyConfig{}, true) // nil DB and no latency scoring for testing.
+	CHALLENGES = services.CreateChallengeRegistry(CHALLENGE_SERVICE_IMPL)
 )
 
 func TestGenerateUniqueFrontendChallenge(t *testing.T) {
-	firstAliens := CHALLENGE_SERVICE_IMPL.GenerateUniqueFrontendChallenge(UUID)
-	secondAliens := CHALLENGE_SERVICE_IMPL.GenerateUniqueFrontendChallenge(UUID)
+	firstAliens := CHALLENGE_SERVICE_IMPL.GenerateUniqueFrontendChallenge(UUID, services.CURRENT_CHALLENGE_VERSION)
+	secondAliens := CHALLENGE_SERVICE_IMPL.GenerateUniqueFrontendChallenge(UUID, services.CURRENT_CHALLENGE_VERSION)
 
 	assert.Equal(t, firstAliens, secondAliens)
 
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"generate_technical_challenge_2025/internal/utils"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// Version of the generators new members are registered with. Members keep the version they registered
+// with, so bump this and register a new set of generators instead of editing an existing one.
+const CURRENT_CHALLENGE_VERSION = 5
+
+// Generators for every challenge, all seeded from the member's ID.
+type ChallengeGenerators struct {
+	Alien    func(memberID uuid.UUID) map[uuid.UUID]InvasionState
+	Frontend func(memberID uuid.UUID) []DetailedAlien
+	Ngrok    func(memberID uuid.UUID) NgrokChallenge
+}
+
+// Every version that has ever been handed out to a member. Once a version has been handed out, any change to
+// its generators (constants, RNG call order, etc.) changes the challenges that members already received.
+var challengeGeneratorVersions = map[int]ChallengeGenerators{
+	1: {
+		Alien:    generateAlienChallengeV1,
+		Frontend: generateFrontendChallengeV1,
+		Ngrok:    generateNgrokChallengeV1,
+	},
+	// Versions 2 and 3 were never handed out. Grading by ID needs unique IDs, so this version posts aliens with
+	// unique IDs together with the single alien, pagination, sorting, PATCH and DELETE by ID, compound, unknown,
+	// repeated, and malformed query, and invalid POST checks to the ngrok challenge.
+	4: {
+		Alien:    generateAlienChallengeV1,
+		Frontend: generateFrontendChallengeV1,
+		Ngrok:    generateNgrokChallengeV4,
+	},
+	// Adds a concurrent POST and repeated DELETE stress round to the end of the ngrok challenge.
+	5: {
+		Alien:    generateAlienChallengeV1,
+		Frontend: generateFrontendChallengeV1,
+		Ngrok:    generateNgrokChallengeV5,
+	},
+}
+
+// Returns the generators for the given version, and whether the version exists.
+func GetChallengeGenerators(version int) (ChallengeGenerators, bool) {
+	generators, ok := challengeGeneratorVersions[version]
+	return generators, ok
+}
+
+func generateFrontendChallengeV1(id uuid.UUID) []DetailedAlien {
+	rng := utils.CreateRNGFromHash(id)
+	numAliens := utils.GenerateRandomNumWithinRange(rng, LOWER_DETAILED_ALIEN_AMOUNT, UPPER_DETAILED_ALIEN_AMOUNT)
+
+	aliens := []DetailedAlien{}
+	for idx := range numAliens {
+		alien := GenerateDetailedAlien(rng, id, idx)
+		aliens = append(aliens, alien)
+	}
+
+	return aliens
+}
+
+func generateAlienChallengeV1(id uuid.UUID) map[uuid.UUID]InvasionState {
+	rng := utils.CreateRNGFromHash(id)
+	maps := map[uuid.UUID]InvasionState{}
+	for range NUM_WAVES {
+		aliens := GenerateAlienInvasion(rng)
+		hp := utils.GenerateRandomNumWithinRange(rng, LOWER_HP_BOUND, UPPER_HP_BOUND)
+		invasionState := CreateInvasionState(aliens, hp)
+		// Read the ID straight from the member's RNG, never through the process wide uuid source.
+		challengeUUID := uuid.Must(uuid.NewRandomFromReader(rng))
+		maps[challengeUUID] = invasionState
+	}
+	return maps
+}
+
+func generateNgrokChallengeV1(memberID uuid.UUID) NgrokChallenge {
+	rng := utils.CreateRNGFromHash(memberID)
+	aliens := GenerateNgrokAliens(rng, memberID)
+
+	// Initial required requests.
+	requests := []NgrokRequest{
+		generateDeleteRequest(),
+		generatePostRequest(aliens),
+		generateGetAllRequest(aliens),
+	}
+
+	// Randomized filter requests.
+	requests = append(requests, GenerateRandomFilterTests(rng, slices.Clone(aliens))...)
+
+	return NgrokChallenge{Requests: requests}
+}
+
+func generateNgrokChallengeV4(memberID uuid.UUID) NgrokChallenge {
+	rng := utils.CreateRNGFromHash(memberID)
+	// Candidates are expected to reject duplicate IDs, so the posted aliens must not have any.
+	aliens := GenerateUniqueNgrokAliens(rng, memberID)
+
+	// Initial required requests.
+	requests := []NgrokRequest{
+		generateDeleteRequest(),
+		generatePostRequest(aliens),
+		generateGetAllRequest(aliens),
+	}
+
+	// Randomized filter requests.
+	requests = append(requests, GenerateRandomFilterTests(rng, slices.Clone(aliens))...)
+
+	// Randomized compound and malformed query requests, read only so they go before any updates.
+	requests = append(requests, GenerateCompoundFilterTests(rng, slices.Clone(aliens))...)
+
+	// Randomized single alien, pagination, sorting, and update requests.
+	reads, updates := GenerateExtendedTests(rng, slices.Clone(aliens))
+	requests = append(requests, reads...)
+
+	// Invalid requests expect the posted aliens to be untouched, so they go before the PATCH and DELETE.
+	requests = append(requests, GenerateValidationTests(rng, memberID, slices.Clone(aliens))...)
+	requests = append(requests, updates...)
+
+	return NgrokChallenge{Requests: matchAliensOnce(requests)}
+}
+
+func generateNgrokChallengeV5(memberID uuid.UUID) NgrokChallenge {
+	challenge := generateNgrokChallengeV4(memberID)
+
+	// The stress round only reposts the same aliens, so it does not draw from the RNG.
+	aliens := GenerateUniqueNgrokAliens(utils.CreateRNGFromHash(memberID), memberID)
+	challenge.Requests = append(challenge.Requests, GenerateStressTest(aliens))
+
+	return challenge
+}
+
+// Makes every GET request match each returned alien to at most one expected alien, see CalculateMatchedAlienDistance.
+func matchAliensOnce(requests []NgrokRequest) []NgrokRequest {
+	return lo.Map(requests, func(request NgrokRequest, _ int) NgrokRequest {
+		switch req := request.(type) {
+		case NgrokGetRequest:
+			req.MatchOnce = true
+			return req
+		case NgrokInvalidPostRequest:
+			req.Verify.MatchOnce = true
+			return req
+		}
+		return request
+	})
+}
@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"generate_technical_challenge_2025/internal/services"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var GOLDEN_MEMBER_ID = uuid.MustParse("17aa5a93-73fc-4f8c-9977-2994481213be")
+
+func fingerprint(t *testing.T, value any) string {
+	bytes, err := json.Marshal(value)
+	require.NoError(t, err)
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:])
+}
+
+func alienChallengeFingerprint(t *testing.T, waves map[uuid.UUID]services.InvasionState) string {
+	keys := []string{}
+	for key := range waves {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	serialized := []any{}
+	for _, key := range keys {
+		wave := waves[uuid.MustParse(key)]
+		serialized = append(serialized, []any{key, wave.SurveyRemainingAlienInvasion(), wave.GetHpLeft()})
+	}
+	return fingerprint(t, serialized)
+}
+
+func ngrokChallengeFingerprint(t *testing.T, challenge services.NgrokChallenge) string {
+	serialized := []any{}
+	for _, request := range challenge.Requests {
+		switch req := request.(type) {
+		case services.NgrokPostRequest:
+			serialized = append(serialized, []any{req.Name, req.Points, req.Path, req.Body})
+		case services.NgrokGetRequest:
+			serialized = append(serialized, []any{req.Name, req.Points, req.Path, req.ExpectedAliens})
+		case services.NgrokGetAlienRequest:
+			serialized = append(serialized, []any{req.Name, req.Points, req.Path, req.ExpectedAlien})
+		case services.NgrokPatchRequest:
+			serialized = append(serialized, []any{req.Name, req.Points, req.Path, req.Body, req.ExpectedAlien})
+		case services.NgrokStressRequest:
+			serialized = append(serialized, []any{req.Name, req.GetTotalPossiblePoints(), req.Path, req.Batches})
+		case services.NgrokInvalidPostRequest:
+			serialized = append(serialized, []any{req.Name, req.Points, req.Path, req.Body, req.Verify.Points, req.Verify.ExpectedAliens})
+		default:
+			serialized = append(serialized, []any{req.GetName(), req.GetTotalPossiblePoints(), fmt.Sprintf("%T", req)})
+		}
+	}
+	return fingerprint(t, serialized)
+}
+
+// Members keep the challenge version they registered with, so a version's challenges must never change.
+// If this fails, register a new version in challengeGeneratorVersions instead of editing an existing one.
+func TestChallengeVersionOneIsStable(t *testing.T) {
+	generators, ok := services.GetChallengeGenerators(1)
+	require.True(t, ok)
+	assert.Equal(t, "e70bf10c11ab50c769aa65fce03a24283db6e90ab1918d108b528d4fde1ed195",
+		alienChallengeFingerprint(t, generators.Alien(GOLDEN_MEMBER_ID)))
+	assert.Equal(t, "deadc9756a968a4a94b6e1017adb5a720825b367f9af4bb4049cf8dd15363bdf",
+		fingerprint(t, generators.Frontend(GOLDEN_MEMBER_ID)))
+	assert.Equal(t, "fb307d509d13d37dc5b79e19f0daca9f6ac5a4a48122553e027fa8d22c469b6b", ngrokChallengeFingerprint(t, generators.Ngrok(GOLDEN_MEMBER_ID)))
+}
+
+// Versions 2 and 3 were never handed out, so nothing should be able to register a member with them.
+func TestChallengeVersionsTwoAndThreeAreNotRegistered(t *testing.T) {
+	for _, version := range []int{2, 3} {
+		_, ok := services.GetChallengeGenerators(version)
+		assert.False(t, ok, version)
+	}
+}
+
+func TestChallengeVersionFourIsStable(t *testing.T) {
+	generators, ok := services.GetChallengeGenerators(4)
+	require.True(t, ok)
+	assert.Equal(t, "e70bf10c11ab50c769aa65fce03a24283db6e90ab1918d108b528d4fde1ed195",
+		alienChallengeFingerprint(t, generators.Alien(GOLDEN_MEMBER_ID)))
+	assert.Equal(t, "deadc9756a968a4a94b6e1017adb5a720825b367f9af4bb4049cf8dd15363bdf",
+		fingerprint(t, generators.Frontend(GOLDEN_MEMBER_ID)))
+	assert.Equal(t, "aaa95708e71f9bed6b5edcc3e2f5fff3f6daac86df362c3721d2459f4e95bbba", ngrokChallengeFingerprint(t, generators.Ngrok(GOLDEN_MEMBER_ID)))
+}
+
+func TestChallengeVersionFiveIsStable(t *testing.T) {
+	generators, ok := services.GetChallengeGenerators(5)
+	require.True(t, ok)
+	assert.Equal(t, "e70bf10c11ab50c769aa65fce03a24283db6e90ab1918d108b528d4fde1ed195",
+		alienChallengeFingerprint(t, generators.Alien(GOLDEN_MEMBER_ID)))
+	assert.Equal(t, "deadc9756a968a4a94b6e1017adb5a720825b367f9af4bb4049cf8dd15363bdf",
+		fingerprint(t, generators.Frontend(GOLDEN_MEMBER_ID)))
+	assert.Equal(t, "8f17ca33032fd4e731ab59b0ba0c017cc7c2c58a7c58ec7cca4320bef09ecfa1", ngrokChallengeFingerprint(t, generators.Ngrok(GOLDEN_MEMBER_ID)))
+}
+
+func TestCurrentChallengeVersionIsRegistered(t *testing.T) {
+	_, ok := services.GetChallengeGenerators(services.CURRENT_CHALLENGE_VERSION)
+	assert.True(t, ok)
+}
+
+// Challenge IDs come from each member's own RNG, so generating concurrently (alongside unrelated calls to
+// uuid.New, like registering members) must never change them.
+func TestAlienChallengeIDsStableUnderConcurrency(t *testing.T) {
+	memberIDs := make([]uuid.UUID, 20)
+	expected := map[uuid.UUID]string{}
+	for idx := range memberIDs {
+		memberIDs[idx] = uuid.New()
+		expected[memberIDs[idx]] = alienChallengeFingerprint(t,
+			CHALLENGE_SERVICE_IMPL.GenerateUniqueAlienChallenge(memberIDs[idx], services.CURRENT_CHALLENGE_VERSION))
+	}
+
+	var wg sync.WaitGroup
+	for worker := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := range 20 {
+				memberID := memberIDs[(worker+round)%len(memberIDs)]
+				challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueAlienChallenge(memberID, services.CURRENT_CHALLENGE_VERSION)
+				assert.Equal(t, expected[memberID], alienChallengeFingerprint(t, challenge))
+				// Unrelated IDs must stay random.
+				assert.NotContains(t, challenge, uuid.New())
+			}
+		}()
+	}
+	wg.Wait()
+}
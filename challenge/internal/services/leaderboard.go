@@ -0,0 +1,170 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/transactions"
+	"generate_technical_challenge_2025/internal/utils"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type LeaderboardService interface {
+	GetLeaderboard(challengeType string, cohortName string) (Leaderboard, error)
+}
+
+// How often leaderboards are read from the database, and how many members they rank.
+type LeaderboardConfig struct {
+	CacheTTL time.Duration // Leaderboards and cohorts are served from memory until they are this old, 0 to always read them.
+	Size     int           // 0 to rank every member with a valid score.
+}
+
+func CreateLeaderboardConfig(env utils.EnvConfig) LeaderboardConfig {
+	return LeaderboardConfig{
+		CacheTTL: env.LEADERBOARD_CACHE_TTL,
+		Size:     env.LEADERBOARD_SIZE,
+	}
+}
+
+// Best valid score of every member at a challenge, best first. Scores of every challenge are lower-is-better.
+// Only scores of one challenge version are ranked, since each version grades differently.
+type Leaderboard struct {
+	ChallengeType    string
+	ChallengeVersion int
+	GeneratedAt      time.Time
+	Entries          []LeaderboardEntry
+}
+
+type LeaderboardEntry struct {
+	Rank     int
+	Handle   string // See MemberHandle.
+	Score    int
+	Attempts int
+	BestAt   time.Time
+}
+
+type leaderboardKey struct {
+	challengeType string
+	cohortID      uuid.UUID
+}
+
+type LeaderboardServiceImpl struct {
+	logger             *slog.Logger
+	transactions       transactions.MemberTransactions
+	cohortTransactions transactions.CohortTransactions
+	challenges         *ChallengeRegistry
+	config             LeaderboardConfig
+	// Held while a leaderboard is read, so a burst of requests for a stale leaderboard reads it once.
+	mu    sync.Mutex
+	cache map[leaderboardKey]Leaderboard
+	// Cohorts are looked up by name in here, so that unknown names never reach the database.
+	cohorts       []models.Cohort
+	cohortsReadAt time.Time
+}
+
+// GetLeaderboard implements LeaderboardService.
+// Ranks members of the named cohort at its challenge version, or members of every cohort at
+// CURRENT_CHALLENGE_VERSION when given no name. Returns ErrUnknownChallengeType if the challenge is not registered,
+// before anything is read so that only leaderboards that exist are ever cached, and ErrCohortNotFound if there is
+// no such cohort.
+func (l *LeaderboardServiceImpl) GetLeaderboard(challengeType string, cohortName string) (Leaderboard, error) {
+	if _, ok := l.challenges.Get(challengeType); !ok {
+		return Leaderboard{}, fmt.Errorf("%w: %s", ErrUnknownChallengeType, challengeType)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cohortID, version := uuid.Nil, CURRENT_CHALLENGE_VERSION
+	if cohortName != "" {
+		cohort, err := l.getCohort(cohortName)
+		if err != nil {
+			return Leaderboard{}, err
+		}
+		cohortID, version = cohort.ID, cohort.ChallengeVersion
+	}
+
+	key := leaderboardKey{challengeType, cohortID}
+	if cached, ok := l.cache[key]; ok && time.Since(cached.GeneratedAt) < l.config.CacheTTL {
+		return cached, nil
+	}
+
+	best, err := l.transactions.GetBestScores(challengeType, cohortID, version, l.config.Size)
+	if err != nil {
+		return Leaderboard{}, err
+	}
+	leaderboard := Leaderboard{
+		ChallengeType:    challengeType,
+		ChallengeVersion: version,
+		GeneratedAt:      time.Now(),
+		Entries: lo.Map(best, func(score models.BestScore, idx int) LeaderboardEntry {
+			return LeaderboardEntry{
+				Rank:     idx + 1,
+				Handle:   MemberHandle(score.UserID),
+				Score:    score.Score,
+				Attempts: score.Attempts,
+				BestAt:   score.BestAt,
+			}
+		}),
+	}
+	l.cache[key] = leaderboard
+	return leaderboard, nil
+}
+
+// Returns the cohort with the given name from the cached cohorts, reading them again once they are stale.
+// Must be called with mu held.
+func (l *LeaderboardServiceImpl) getCohort(name string) (models.Cohort, error) {
+	if l.cohortsReadAt.IsZero() || time.Since(l.cohortsReadAt) >= l.config.CacheTTL {
+		cohorts, err := l.cohortTransactions.GetCohorts()
+		if err != nil {
+			return models.Cohort{}, err
+		}
+		l.cohorts, l.cohortsReadAt = cohorts, time.Now()
+	}
+	cohort, ok := lo.Find(l.cohorts, func(cohort models.Cohort) bool { return cohort.Name == name })
+	if !ok {
+		return models.Cohort{}, ErrCohortNotFound
+	}
+	return cohort, nil
+}
+
+var (
+	handleAdjectives = []string{
+		"brave", "swift", "silent", "cosmic", "lunar", "solar", "stellar", "orbital",
+		"radiant", "crimson", "golden", "silver", "frozen", "blazing", "hidden", "restless",
+		"curious", "clever", "nimble", "steady", "bold", "quiet", "wandering", "distant",
+		"electric", "magnetic", "binary", "quantum", "rapid", "patient", "vivid", "ancient",
+	}
+	handleNouns = []string{
+		"comet", "nebula", "pulsar", "quasar", "meteor", "asteroid", "galaxy", "nova",
+		"rocket", "satellite", "probe", "orbit", "eclipse", "horizon", "cosmos", "photon",
+		"falcon", "otter", "fox", "owl", "lynx", "raven", "badger", "heron",
+		"defender", "pilot", "ranger", "scout", "sentinel", "voyager", "captain", "cadet",
+	}
+)
+
+// Pseudonym shown instead of the member's email, e.g. brave-comet-4821. It is derived from the member's ID, so it
+// never changes and members can find themselves, but it cannot be turned back into the ID.
+func MemberHandle(memberID uuid.UUID) string {
+	hash := sha256.Sum256(memberID[:])
+	adjective := handleAdjectives[binary.BigEndian.Uint32(hash[0:4])%uint32(len(handleAdjectives))]
+	noun := handleNouns[binary.BigEndian.Uint32(hash[4:8])%uint32(len(handleNouns))]
+	return fmt.Sprintf("%s-%s-%04d", adjective, noun, binary.BigEndian.Uint32(hash[8:12])%10000)
+}
+
+func CreateLeaderboardService(logger *slog.Logger, transactions transactions.MemberTransactions, cohortTransactions transactions.CohortTransactions, challenges *ChallengeRegistry, config LeaderboardConfig) LeaderboardService {
+	return &LeaderboardServiceImpl{
+		logger:             logger,
+		transactions:       transactions,
+		cohortTransactions: cohortTransactions,
+		challenges:         challenges,
+		config:             config,
+		cache:              map[leaderboardKey]Leaderboard{},
+	}
+}
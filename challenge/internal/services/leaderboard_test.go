@@ -0,0 +1,136 @@
+package services_test
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/transactions"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Serves best scores from memory, counting how often they are read.
+type stubBestScores struct {
+	transactions.MemberTransactions
+	best     []models.BestScore
+	reads    int
+	versions []int // Version of every read.
+}
+
+func (s *stubBestScores) GetBestScores(challengeType string, cohortID uuid.UUID, challengeVersion int, limit int) ([]models.BestScore, error) {
+	s.reads++
+	s.versions = append(s.versions, challengeVersion)
+	return s.best, nil
+}
+
+// Serves cohorts from memory, counting how often they are read.
+type stubLeaderboardCohorts struct {
+	transactions.CohortTransactions
+	cohorts []models.Cohort
+	reads   int
+}
+
+func (s *stubLeaderboardCohorts) GetCohorts() ([]models.Cohort, error) {
+	s.reads++
+	return s.cohorts, nil
+}
+
+func TestMemberHandleIsStable(t *testing.T) {
+	memberID := uuid.New()
+	handle := services.MemberHandle(memberID)
+	assert.Equal(t, handle, services.MemberHandle(memberID))
+	assert.Regexp(t, regexp.MustCompile(`^[a-z]+-[a-z]+-\d{4}$`), handle)
+	assert.NotEqual(t, handle, services.MemberHandle(uuid.New()))
+}
+
+func TestLeaderboardRanksBestScores(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	bestAt := time.Now().Add(-time.Hour)
+	stub := &stubBestScores{best: []models.BestScore{
+		{UserID: first, Email: "first@northeastern.edu", Score: 0, Attempts: 4, BestAt: bestAt},
+		{UserID: second, Email: "second@northeastern.edu", Score: 3, Attempts: 1, BestAt: bestAt},
+	}}
+	leaderboards := services.CreateLeaderboardService(LOGGER, stub, &stubLeaderboardCohorts{}, CHALLENGES, services.LeaderboardConfig{})
+
+	leaderboard, err := leaderboards.GetLeaderboard(models.ALGORITHM_CHALLENGE_TYPE, "")
+	assert.NoError(t, err)
+	assert.Equal(t, models.ALGORITHM_CHALLENGE_TYPE, leaderboard.ChallengeType)
+	assert.Equal(t, services.CURRENT_CHALLENGE_VERSION, leaderboard.ChallengeVersion)
+	assert.Equal(t, []services.LeaderboardEntry{
+		{Rank: 1, Handle: services.MemberHandle(first), Score: 0, Attempts: 4, BestAt: bestAt},
+		{Rank: 2, Handle: services.MemberHandle(second), Score: 3, Attempts: 1, BestAt: bestAt},
+	}, leaderboard.Entries)
+}
+
+func TestLeaderboardIsCached(t *testing.T) {
+	stub := &stubBestScores{}
+	cohort := models.CreateCohort("Fall", time.Now(), time.Time{}, nil, services.CURRENT_CHALLENGE_VERSION)
+	leaderboards := services.CreateLeaderboardService(LOGGER, stub, &stubLeaderboardCohorts{cohorts: []models.Cohort{*cohort}}, CHALLENGES,
+		services.LeaderboardConfig{CacheTTL: time.Hour})
+
+	for range 5 {
+		_, err := leaderboards.GetLeaderboard(models.NGROK_CHALLENGE_TYPE, "")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, stub.reads)
+
+	// Every challenge and cohort has its own leaderboard.
+	_, err := leaderboards.GetLeaderboard(models.ALGORITHM_CHALLENGE_TYPE, "")
+	assert.NoError(t, err)
+	_, err = leaderboards.GetLeaderboard(models.NGROK_CHALLENGE_TYPE, cohort.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stub.reads)
+}
+
+func TestLeaderboardWithoutCacheAlwaysReads(t *testing.T) {
+	stub := &stubBestScores{}
+	leaderboards := services.CreateLeaderboardService(LOGGER, stub, &stubLeaderboardCohorts{}, CHALLENGES, services.LeaderboardConfig{})
+
+	for range 3 {
+		_, err := leaderboards.GetLeaderboard(models.NGROK_CHALLENGE_TYPE, "")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, stub.reads)
+}
+
+// Each version grades differently, so a cohort is ranked at its own version.
+func TestLeaderboardRanksTheCohortVersion(t *testing.T) {
+	stub := &stubBestScores{}
+	cohort := models.CreateCohort("Fall", time.Now(), time.Time{}, nil, 1)
+	leaderboards := services.CreateLeaderboardService(LOGGER, stub, &stubLeaderboardCohorts{cohorts: []models.Cohort{*cohort}}, CHALLENGES,
+		services.LeaderboardConfig{})
+
+	leaderboard, err := leaderboards.GetLeaderboard(models.NGROK_CHALLENGE_TYPE, cohort.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, leaderboard.ChallengeVersion)
+	_, err = leaderboards.GetLeaderboard(models.NGROK_CHALLENGE_TYPE, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, services.CURRENT_CHALLENGE_VERSION}, stub.versions)
+}
+
+func TestLeaderboardResolvesCohortsFromCache(t *testing.T) {
+	stub := &stubBestScores{}
+	cohorts := &stubLeaderboardCohorts{}
+	leaderboards := services.CreateLeaderboardService(LOGGER, stub, cohorts, CHALLENGES, services.LeaderboardConfig{CacheTTL: time.Hour})
+
+	for range 5 {
+		_, err := leaderboards.GetLeaderboard(models.NGROK_CHALLENGE_TYPE, uuid.NewString())
+		assert.ErrorIs(t, err, services.ErrCohortNotFound)
+	}
+	assert.Equal(t, 1, cohorts.reads)
+	assert.Zero(t, stub.reads)
+}
+
+func TestLeaderboardRejectsUnknownChallenges(t *testing.T) {
+	stub := &stubBestScores{}
+	cohorts := &stubLeaderboardCohorts{}
+	leaderboards := services.CreateLeaderboardService(LOGGER, stub, cohorts, CHALLENGES, services.LeaderboardConfig{CacheTTL: time.Hour})
+
+	_, err := leaderboards.GetLeaderboard("design", "Fall")
+	assert.ErrorIs(t, err, services.ErrUnknownChallengeType)
+	assert.Zero(t, cohorts.reads)
+	assert.Zero(t, stub.reads)
+}
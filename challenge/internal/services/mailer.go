@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"generate_technical_challenge_2025/internal/utils"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sends plain text emails to members.
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+var (
+	ErrUnknownMailer  = errors.New("unknown mailer")
+	ErrMailerConfig   = errors.New("mailer is missing configuration")
+	ErrInvalidHeaders = errors.New("email headers cannot contain line breaks")
+)
+
+// Creates the mailer chosen by env.MAILER.
+func CreateMailer(env utils.EnvConfig, logger *slog.Logger) (Mailer, error) {
+	switch env.MAILER {
+	case "log":
+		return LogMailer{logger: logger}, nil
+	case "file":
+		return &FileMailer{path: env.MAIL_FILE}, nil
+	case "smtp":
+		if env.SMTP_HOST == "" || env.MAIL_FROM == "" {
+			return nil, fmt.Errorf("%w: SMTP_HOST and MAIL_FROM are required", ErrMailerConfig)
+		}
+		return CreateSMTPMailer(env.SMTP_HOST, env.SMTP_PORT, env.SMTP_USERNAME, env.SMTP_PASSWORD, env.MAIL_FROM), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMailer, env.MAILER)
+	}
+}
+
+// Sends emails through an SMTP server, upgrading to TLS when the server supports it.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth // nil for servers that do not need authentication.
+}
+
+func CreateSMTPMailer(host string, port int, username string, password string, from string) SMTPMailer {
+	mailer := SMTPMailer{addr: net.JoinHostPort(host, strconv.Itoa(port)), from: from}
+	if username != "" {
+		mailer.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return mailer
+}
+
+// Send implements Mailer.
+// net/smtp cannot be cancelled, so ctx is only checked before connecting.
+func (m SMTPMailer) Send(ctx context.Context, email Email) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	message, err := formatEmail(m.from, email)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{email.To}, []byte(message))
+}
+
+// Writes emails to the log instead of sending them, for local development.
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+// Send implements Mailer.
+func (m LogMailer) Send(ctx context.Context, email Email) error {
+	m.logger.Info("Email", "to", email.To, "subject", email.Subject, "body", email.Body)
+	return nil
+}
+
+// Appends emails to a file instead of sending them, for local development.
+type FileMailer struct {
+	path string
+	mu   sync.Mutex // Keeps emails sent at the same time from interleaving.
+}
+
+// Send implements Mailer.
+func (m *FileMailer) Send(ctx context.Context, email Email) error {
+	message, err := formatEmail("", email)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	file, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteString(message + "\r\n")
+	return errors.Join(err, file.Close())
+}
+
+// Formats the email as a message for SMTP, leaving out the From header when from is empty.
+func formatEmail(from string, email Email) (string, error) {
+	if strings.ContainsAny(from+email.To+email.Subject, "\r\n") {
+		return "", ErrInvalidHeaders
+	}
+	var message strings.Builder
+	if from != "" {
+		fmt.Fprintf(&message, "From: %s\r\n", from)
+	}
+	fmt.Fprintf(&message, "To: %s\r\n", email.To)
+	fmt.Fprintf(&message, "Subject: %s\r\n", email.Subject)
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(email.Body, "\n", "\r\n"))
+	message.WriteString("\r\n")
+	return message.String(), nil
+}
@@ -0,0 +1,42 @@
+package services_test
+
+import (
+	"context"
+	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/utils"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMailer(t *testing.T) {
+	_, err := services.CreateMailer(utils.EnvConfig{MAILER: "log"}, LOGGER)
+	assert.NoError(t, err)
+	_, err = services.CreateMailer(utils.EnvConfig{MAILER: "smtp", SMTP_HOST: "smtp.example.com"}, LOGGER)
+	assert.ErrorIs(t, err, services.ErrMailerConfig)
+	_, err = services.CreateMailer(utils.EnvConfig{MAILER: "pigeon"}, LOGGER)
+	assert.ErrorIs(t, err, services.ErrUnknownMailer)
+}
+
+func TestFileMailerAppendsEmails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	mailer, err := services.CreateMailer(utils.EnvConfig{MAILER: "file", MAIL_FILE: path}, LOGGER)
+	assert.NoError(t, err)
+
+	assert.NoError(t, mailer.Send(context.Background(), services.Email{To: "a@northeastern.edu", Subject: "First", Body: "123456"}))
+	assert.NoError(t, mailer.Send(context.Background(), services.Email{To: "b@northeastern.edu", Subject: "Second", Body: "654321"}))
+	contents, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(contents), "To: a@northeastern.edu\r\nSubject: First\r\n")
+	assert.Contains(t, string(contents), "To: b@northeastern.edu\r\nSubject: Second\r\n")
+	assert.Contains(t, string(contents), "654321")
+}
+
+func TestMailersRejectHeaderInjection(t *testing.T) {
+	mailer, err := services.CreateMailer(utils.EnvConfig{MAILER: "file", MAIL_FILE: filepath.Join(t.TempDir(), "mail.log")}, LOGGER)
+	assert.NoError(t, err)
+	err = mailer.Send(context.Background(), services.Email{To: "a@northeastern.edu\r\nBcc: everyone@example.com", Subject: "Hi"})
+	assert.ErrorIs(t, err, services.ErrInvalidHeaders)
+}
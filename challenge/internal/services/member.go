@@ -1,12 +1,15 @@
 package services
 
 import (
+	"errors"
 	"generate_technical_challenge_2025/internal/database/models"
 	"generate_technical_challenge_2025/internal/transactions"
 	"generate_technical_challenge_2025/internal/utils"
 	"log/slog"
+	"sort"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 // Pointers could be nil or have the actual value, always check if the error is nil
@@ -15,17 +18,55 @@ type MemberService interface {
 	CreateMember(*models.Member) (*uuid.UUID, error)
 	CreateScore(*models.Score) (int, error)
 	LogFrontendUsageAsync(uuid.UUID)
-	GetMember(string, string) (*uuid.UUID, error)
-	CheckMemberExistsByEmailAndNuid(string, string) (bool, error)
+	GetMember(string, string, uuid.UUID) (*models.Member, bool, error)
+	CheckMemberExistsByEmailAndNuid(string, string, uuid.UUID) (bool, error)
 	CheckMemberExistsById(uuid.UUID) (bool, error)
+	GetMemberWithCohort(uuid.UUID) (*models.Member, bool, error)
+	ReserveAttempt(memberID uuid.UUID, challengeType string) (AttemptQuota, error)
+	RefundAttempt(memberID uuid.UUID, challengeType string) error
+	GetAttemptQuotas(memberID uuid.UUID, challengeTypes []string) ([]AttemptQuota, error)
+	ResetAttempts(memberID uuid.UUID, challengeType string) error
+	FindMembers(email string, nuid string) ([]models.Member, error)
+	GetScoreHistory(memberID uuid.UUID) ([]models.Score, error)
+	GetScoreSummaries(memberID uuid.UUID) ([]ScoreSummary, error)
+	GetFrontendUsageSummary(memberID uuid.UUID) (models.FrontendUsageSummary, error)
 }
 
 type MemberServiceImpl struct {
-	logger       *slog.Logger
-	transactions transactions.MemberTransactions
-	usageLogger  *utils.UsageLogger
+	logger        *slog.Logger
+	transactions  transactions.MemberTransactions
+	usageLogger   *utils.UsageLogger
+	attemptLimits map[string]int // Keyed by challenge type, challenges without a limit are left out.
 }
 
+// How many times a member has submitted a challenge, out of their limit.
+type AttemptQuota struct {
+	ChallengeType string
+	Used          int
+	Limit         int // 0 when the challenge has no limit.
+}
+
+// Attempts the member has left, or -1 when the challenge has no limit.
+func (q AttemptQuota) Remaining() int {
+	if q.Limit <= 0 {
+		return -1
+	}
+	return max(q.Limit-q.Used, 0)
+}
+
+// A member's graded submissions for one challenge.
+type ScoreSummary struct {
+	ChallengeType string
+	Attempts      int
+	Best          *models.Score // Lowest valid score, the earliest one on ties. nil when no submission was valid.
+	Latest        models.Score
+}
+
+var (
+	ErrAttemptLimitReached = errors.New("attempt limit reached")
+	ErrNoMemberLookup      = errors.New("an email or NUID is required to look up members")
+)
+
 // LogFrontendUsageAsync implements MemberService.
 // No need to call this within a goroutine, it already handles asynchronicity.
 func (u *MemberServiceImpl) LogFrontendUsageAsync(userID uuid.UUID) {
@@ -42,17 +83,25 @@ func (u *MemberServiceImpl) CheckMemberExistsById(id uuid.UUID) (bool, error) {
 	return u.transactions.MemberExistsById(id)
 }
 
-func CreateMemberService(logger *slog.Logger, transactions transactions.MemberTransactions) MemberService {
+// GetMemberWithCohort implements MemberService.
+// Returns the member, including the version of the challenge generators they were registered with and their
+// cohort, and whether they exist.
+func (u *MemberServiceImpl) GetMemberWithCohort(id uuid.UUID) (*models.Member, bool, error) {
+	return u.transactions.GetMemberWithCohort(id)
+}
+
+func CreateMemberService(logger *slog.Logger, transactions transactions.MemberTransactions, attemptLimits map[string]int) MemberService {
 	usageLogger := utils.NewUsageLogger(transactions)
 	return &MemberServiceImpl{
-		logger:       logger,
-		transactions: transactions,
-		usageLogger:  usageLogger,
+		logger:        logger,
+		transactions:  transactions,
+		usageLogger:   usageLogger,
+		attemptLimits: attemptLimits,
 	}
 }
 
-func (u *MemberServiceImpl) CheckMemberExistsByEmailAndNuid(email string, nuid string) (bool, error) {
-	return u.transactions.MemberExistsByEmailAndNuid(email, nuid)
+func (u *MemberServiceImpl) CheckMemberExistsByEmailAndNuid(email string, nuid string, cohortID uuid.UUID) (bool, error) {
+	return u.transactions.MemberExistsByEmailAndNuid(email, nuid, cohortID)
 }
 
 // CreateUser implements UserService.
@@ -60,6 +109,101 @@ func (u *MemberServiceImpl) CreateMember(member *models.Member) (*uuid.UUID, err
 	return u.transactions.InsertMember(member)
 }
 
-func (u *MemberServiceImpl) GetMember(email string, nuid string) (*uuid.UUID, error) {
-	return u.transactions.GetMember(email, nuid)
+// Looks in the given cohort, or returns the latest registration in any cohort when given uuid.Nil.
+// Returns the member along with their cohort, and whether they exist.
+func (u *MemberServiceImpl) GetMember(email string, nuid string, cohortID uuid.UUID) (*models.Member, bool, error) {
+	return u.transactions.GetMember(email, nuid, cohortID)
+}
+
+// ReserveAttempt implements MemberService.
+// Counts a submission against the member's limit before it is graded, failing with ErrAttemptLimitReached once
+// every attempt has been used.
+func (u *MemberServiceImpl) ReserveAttempt(memberID uuid.UUID, challengeType string) (AttemptQuota, error) {
+	limit := u.attemptLimits[challengeType]
+	used, reserved, err := u.transactions.ReserveAttempt(memberID, challengeType, limit)
+	if err != nil {
+		return AttemptQuota{}, err
+	}
+	quota := AttemptQuota{ChallengeType: challengeType, Used: used, Limit: limit}
+	if !reserved {
+		return quota, ErrAttemptLimitReached
+	}
+	return quota, nil
+}
+
+// RefundAttempt implements MemberService.
+// Only for submissions that were never graded, e.g. because the grading queue was full.
+func (u *MemberServiceImpl) RefundAttempt(memberID uuid.UUID, challengeType string) error {
+	return u.transactions.RefundAttempt(memberID, challengeType)
+}
+
+// GetAttemptQuotas implements MemberService.
+// Returns a quota for every given challenge type, in the same order.
+func (u *MemberServiceImpl) GetAttemptQuotas(memberID uuid.UUID, challengeTypes []string) ([]AttemptQuota, error) {
+	used, err := u.transactions.GetAttemptCounts(memberID)
+	if err != nil {
+		return nil, err
+	}
+	quotas := make([]AttemptQuota, len(challengeTypes))
+	for idx, challengeType := range challengeTypes {
+		quotas[idx] = AttemptQuota{ChallengeType: challengeType, Used: used[challengeType], Limit: u.attemptLimits[challengeType]}
+	}
+	return quotas, nil
+}
+
+// ResetAttempts implements MemberService.
+func (u *MemberServiceImpl) ResetAttempts(memberID uuid.UUID, challengeType string) error {
+	return u.transactions.ResetAttempts(memberID, challengeType)
+}
+
+// FindMembers implements MemberService.
+// Matches on the email, the NUID, or both when both are given. Newest registrations first.
+func (u *MemberServiceImpl) FindMembers(email string, nuid string) ([]models.Member, error) {
+	if email == "" && nuid == "" {
+		return nil, ErrNoMemberLookup
+	}
+	return u.transactions.FindMembers(email, nuid)
+}
+
+// GetScoreHistory implements MemberService.
+// Every graded submission of the member, newest first.
+func (u *MemberServiceImpl) GetScoreHistory(memberID uuid.UUID) ([]models.Score, error) {
+	return u.transactions.GetScores(memberID)
+}
+
+// GetScoreSummaries implements MemberService.
+// One summary for every challenge the member submitted, sorted by challenge type.
+func (u *MemberServiceImpl) GetScoreSummaries(memberID uuid.UUID) ([]ScoreSummary, error) {
+	scores, err := u.transactions.GetScores(memberID)
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeScores(scores), nil
+}
+
+// GetFrontendUsageSummary implements MemberService.
+func (u *MemberServiceImpl) GetFrontendUsageSummary(memberID uuid.UUID) (models.FrontendUsageSummary, error) {
+	return u.transactions.GetFrontendUsageSummary(memberID)
+}
+
+// Summarizes scores, which must be sorted newest first, per challenge type.
+func SummarizeScores(scores []models.Score) []ScoreSummary {
+	summaries := map[string]*ScoreSummary{}
+	for _, score := range scores {
+		summary, ok := summaries[score.ChallengeType]
+		if !ok {
+			summary = &ScoreSummary{ChallengeType: score.ChallengeType, Latest: score}
+			summaries[score.ChallengeType] = summary
+		}
+		summary.Attempts++
+		// Going back in time, so ties move the best score to the earlier submission.
+		if score.IsValid && (summary.Best == nil || score.Score <= summary.Best.Score) {
+			summary.Best = &score
+		}
+	}
+	result := lo.Map(lo.Values(summaries), func(summary *ScoreSummary, _ int) ScoreSummary { return *summary })
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ChallengeType < result[j].ChallengeType
+	})
+	return result
 }
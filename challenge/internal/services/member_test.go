@@ -0,0 +1,58 @@
+package services_test
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttemptQuotaRemaining(t *testing.T) {
+	assert.Equal(t, 3, services.AttemptQuota{Used: 17, Limit: 20}.Remaining())
+	assert.Equal(t, 0, services.AttemptQuota{Used: 20, Limit: 20}.Remaining())
+	// Limits can be lowered below what members have already used.
+	assert.Equal(t, 0, services.AttemptQuota{Used: 25, Limit: 20}.Remaining())
+	assert.Equal(t, -1, services.AttemptQuota{Used: 25}.Remaining())
+}
+
+func TestChallengeAttemptLimitsLeavesOutUnlimitedChallenges(t *testing.T) {
+	env := utils.EnvConfig{ALGORITHM_MAX_ATTEMPTS: 20}
+	assert.Equal(t, map[string]int{models.ALGORITHM_CHALLENGE_TYPE: 20}, env.ChallengeAttemptLimits())
+}
+
+func TestSummarizeScores(t *testing.T) {
+	memberID := uuid.New()
+	start := time.Now()
+	score := func(challengeType string, minutes int, value int, valid bool) models.Score {
+		score := *models.CreateScore(memberID, challengeType, value, valid, services.CURRENT_CHALLENGE_VERSION)
+		score.CreatedAt = start.Add(time.Duration(minutes) * time.Minute)
+		return score
+	}
+	// Newest first, as they are read from the database.
+	scores := []models.Score{
+		score(models.NGROK_CHALLENGE_TYPE, 4, models.INVALID_SCORE, false),
+		score(models.ALGORITHM_CHALLENGE_TYPE, 3, 12, true),
+		score(models.ALGORITHM_CHALLENGE_TYPE, 2, 5, true),
+		score(models.ALGORITHM_CHALLENGE_TYPE, 1, 5, true),
+		score(models.ALGORITHM_CHALLENGE_TYPE, 0, models.INVALID_SCORE, false),
+	}
+
+	summaries := services.SummarizeScores(scores)
+	assert.Len(t, summaries, 2)
+
+	algorithm := summaries[0]
+	assert.Equal(t, models.ALGORITHM_CHALLENGE_TYPE, algorithm.ChallengeType)
+	assert.Equal(t, 4, algorithm.Attempts)
+	assert.Equal(t, scores[1].ID, algorithm.Latest.ID)
+	// The best score was first reached a minute in.
+	assert.Equal(t, scores[3].ID, algorithm.Best.ID)
+
+	ngrok := summaries[1]
+	assert.Equal(t, models.NGROK_CHALLENGE_TYPE, ngrok.ChallengeType)
+	assert.Equal(t, 1, ngrok.Attempts)
+	assert.Nil(t, ngrok.Best)
+}
@@ -7,26 +7,96 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/samber/lo"
 )
 
 type NgrokChallenge struct {
 	Requests []NgrokRequest
+	Version  int // Version of the generators the requests came from.
 }
 
 type NgrokChallengeScore struct {
-	Valid  bool
-	Score  int
-	Reason string // optional, only set when Valid = false
+	Valid   bool
+	Score   int
+	Reason  string               // optional, only set when Valid = false
+	Results []NgrokRequestResult // One per request that was sent, in the order they were sent.
+	Latency NgrokLatency         // Only set when Valid = true.
+}
+
+// Outcome of sending a single NgrokRequest to the candidate's server.
+type NgrokRequestResult struct {
+	Name           string
+	Method         string
+	Path           string // Path and query that was requested.
+	PointsEarned   int
+	PointsPossible int
+	StatusCode     int // 0 if the server never responded.
+	Latency        time.Duration
+	Err            error // optional, only set when the request failed outright.
+
+	// Only set for requests that compare the returned aliens against the expected aliens.
+	Compared      bool
+	ExpectedCount int
+	ActualCount   int
+	Distance      int      // See CalculateAlienDistance and CalculateMatchedAlienDistance.
+	MissingIDs    []string // Sample of expected IDs that were not returned.
+	UnexpectedIDs []string // Sample of returned IDs that were not expected.
+	MismatchedIDs []string // Sample of IDs that were returned with different values than expected.
 }
 
 type NgrokRequest interface {
-	Execute(ctx context.Context, client *http.Client, baseURL string) (pointsEarned int, err error)
+	Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult
 	GetName() string
 	GetTotalPossiblePoints() int
 }
 
+func newNgrokRequestResult(request NgrokRequest, method string, path string) NgrokRequestResult {
+	return NgrokRequestResult{Name: request.GetName(), Method: method, Path: path, PointsPossible: request.GetTotalPossiblePoints()}
+}
+
+// Records how the returned aliens differ from the expected aliens, pairing them up with match.
+func (r *NgrokRequestResult) compare(expected, actual []DetailedAlien, match alienMatcher) {
+	matches := match(expected, actual)
+	r.Compared = true
+	r.ExpectedCount = len(expected)
+	r.ActualCount = len(actual)
+	r.Distance = alienDistance(expected, actual, matches)
+	r.MissingIDs, r.UnexpectedIDs, r.MismatchedIDs = sampleAlienDiff(expected, actual, matches, NGROK_DIFF_SAMPLE_SIZE)
+}
+
+// Records the error on the result, earning no points.
+func (r NgrokRequestResult) fail(err error) NgrokRequestResult {
+	r.PointsEarned = 0
+	r.Err = err
+	return r
+}
+
+// Keeps the first error recorded on the result.
+func (r *NgrokRequestResult) recordError(err error) {
+	if r.Err == nil {
+		r.Err = err
+	}
+}
+
+// Sends the request to the candidate's server, recording the status code and latency on the result.
+func sendNgrokRequest(client *http.Client, req *http.Request, result *NgrokRequestResult) (*http.Response, error) {
+	req.Header.Set("ngrok-skip-browser-warning", "true")
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	result.Latency = time.Since(start)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	result.StatusCode = resp.StatusCode
+	return resp, nil
+}
+
 type NgrokDeleteRequest struct {
 	Name   string
 	Points int
@@ -45,6 +115,9 @@ type NgrokGetRequest struct {
 	Points         int
 	Path           string
 	ExpectedAliens []DetailedAlien
+	SortField      string   // optional, when set the aliens must also come back ordered by this field.
+	MatchOnce      bool     // optional, when set the aliens are compared with CalculateMatchedAlienDistance.
+	OnlyIDs        []string // optional, when set only expected and returned aliens with these IDs are compared.
 }
 
 func (t NgrokDeleteRequest) GetName() string {
@@ -82,103 +155,119 @@ const (
 	HP  = "hp"
 )
 
-func (t NgrokDeleteRequest) Execute(ctx context.Context, client *http.Client, baseURL string) (int, error) {
+func (t NgrokDeleteRequest) Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult {
+	result := newNgrokRequestResult(t, http.MethodDelete, t.Path)
+
 	req, err := http.NewRequestWithContext(ctx, "DELETE", baseURL+t.Path, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		return result.fail(fmt.Errorf("failed to create request: %w", err))
 	}
 
-	req.Header.Set("ngrok-skip-browser-warning", "true")
-
-	resp, err := client.Do(req)
+	resp, err := sendNgrokRequest(client, req, &result)
 	if err != nil {
-		return 0, fmt.Errorf("HTTP request failed: %w", err)
+		return result.fail(err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("expected status 204 or 200, got %d", resp.StatusCode)
+		return result.fail(fmt.Errorf("expected status 204 or 200, got %d", resp.StatusCode))
 	}
 
-	return 0, nil
+	result.PointsEarned = t.Points
+	return result
 }
 
-func (t NgrokPostRequest) Execute(ctx context.Context, client *http.Client, baseURL string) (int, error) {
+func (t NgrokPostRequest) Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult {
+	result := newNgrokRequestResult(t, http.MethodPost, t.Path)
+
 	// Marshal body to JSON
 	bodyBytes, err := json.Marshal(t.Body)
 	if err != nil {
-		return 0, fmt.Errorf("failed to marshal request body: %w", err)
+		return result.fail(fmt.Errorf("failed to marshal request body: %w", err))
 	}
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+t.Path, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		return result.fail(fmt.Errorf("failed to create request: %w", err))
 	}
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("ngrok-skip-browser-warning", "true")
 
 	// Make request
-	resp, err := client.Do(req)
+	resp, err := sendNgrokRequest(client, req, &result)
 	if err != nil {
-		return 0, fmt.Errorf("HTTP request failed: %w", err)
+		return result.fail(err)
 	}
 	defer resp.Body.Close()
 
 	// Check status code (expect 201 Created for POST)
 	if resp.StatusCode != http.StatusCreated {
-		return 0, fmt.Errorf("expected status 201, got %d", resp.StatusCode)
+		return result.fail(fmt.Errorf("expected status 201, got %d", resp.StatusCode))
 	}
 
-	return NGROK_POST_POINTS, nil
+	result.PointsEarned = NGROK_POST_POINTS
+	return result
 }
 
-func (t NgrokGetRequest) Execute(ctx context.Context, client *http.Client, baseURL string) (int, error) {
+func (t NgrokGetRequest) Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult {
+	result := newNgrokRequestResult(t, http.MethodGet, t.Path)
+
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+t.Path, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		return result.fail(fmt.Errorf("failed to create request: %w", err))
 	}
 
-	// Set headers
-	req.Header.Set("ngrok-skip-browser-warning", "true")
-
 	// Make request
-	resp, err := client.Do(req)
+	resp, err := sendNgrokRequest(client, req, &result)
 	if err != nil {
-		return 0, fmt.Errorf("HTTP request failed: %w", err)
+		return result.fail(err)
 	}
 	defer resp.Body.Close()
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("expected status 200, got %d", resp.StatusCode)
+		return result.fail(fmt.Errorf("expected status 200, got %d", resp.StatusCode))
 	}
 
 	// Parse response
 	var actualAliens []DetailedAlien
 	if err := json.NewDecoder(resp.Body).Decode(&actualAliens); err != nil {
-		return 0, fmt.Errorf("invalid JSON response: %w", err)
+		return result.fail(fmt.Errorf("invalid JSON response: %w", err))
+	}
+
+	expectedAliens := t.ExpectedAliens
+	if t.OnlyIDs != nil {
+		hasOnlyID := func(alien DetailedAlien, _ int) bool { return slices.Contains(t.OnlyIDs, alien.ID) }
+		expectedAliens, actualAliens = lo.Filter(expectedAliens, hasOnlyID), lo.Filter(actualAliens, hasOnlyID)
 	}
 
 	// Calculate distance and adjust points.
-	distance := CalculateAlienDistance(t.ExpectedAliens, actualAliens)
+	match := collapseAliensByID
+	if t.MatchOnce {
+		match = matchAliensByID
+	}
+	result.compare(expectedAliens, actualAliens, match)
+	if t.SortField != "" {
+		result.Distance += CalculateSortDistance(expectedAliens, actualAliens, t.SortField)
+	}
+	distance := result.Distance
 
 	if distance == 0 {
 		// Perfect match.
-		return t.Points, nil
+		result.PointsEarned = t.Points
 	} else {
 		// Partial credit.
-		adjustedPoints := max(t.Points-distance, 0)
+		result.PointsEarned = max(t.Points-distance, 0)
 
 		if VERBOSE {
 			fmt.Printf("distance_error:%d:expected %d aliens, got %d aliens with %d differences\n",
-				distance, len(t.ExpectedAliens), len(actualAliens), distance)
+				distance, len(expectedAliens), len(actualAliens), distance)
 		}
-		return adjustedPoints, nil
 	}
+	return result
 }
 
 // Returns the 'distance' between the expected and actual alien sets.
@@ -192,43 +281,43 @@ func (t NgrokGetRequest) Execute(ctx context.Context, client *http.Client, baseU
 // - There is no double-counting of distance--if there is an alien in actual with the same ID as one in
 // expected but different Atk and Spd, it will still only have a distance of 1.
 func CalculateAlienDistance(expected, actual []DetailedAlien) int {
-	distance := 0
+	return alienDistance(expected, actual, collapseAliensByID(expected, actual))
+}
 
-	expectedMap := make(map[string]DetailedAlien)
-	actualMap := make(map[string]DetailedAlien)
+// Like CalculateAlienDistance, except each actual alien is only matched to one expected alien, see
+// matchAliensByID. Versions before 4 posted aliens whose IDs could collide, and keep CalculateAlienDistance.
+func CalculateMatchedAlienDistance(expected, actual []DetailedAlien) int {
+	return alienDistance(expected, actual, matchAliensByID(expected, actual))
+}
 
-	for _, alien := range expected {
-		expectedMap[alien.ID] = alien
-	}
-	for _, alien := range actual {
-		actualMap[alien.ID] = alien
-	}
+func alienDistance(expected, actual []DetailedAlien, matches []alienMatch) int {
+	distance := 0
 
 	contentDiffs := 0
 
 	// Check aliens that exist in both sets.
-	for _, expectedAlien := range expected {
-		if actualAlien, exists := actualMap[expectedAlien.ID]; exists {
-
-			if VERBOSE {
-				fmt.Println()
-				fmt.Printf("Expected alien: %+v\n", expectedAlien)
-				fmt.Printf("Actual alien: %+v\n", actualAlien)
-				fmt.Println()
-			}
-
-			if !aliensEqual(expectedAlien, actualAlien) {
-				contentDiffs++
-			}
-		} else {
+	for _, match := range matches {
+		if match.actual == nil {
 			// Key/value pair doesn't exist in the actual set.
 			// Therefore, either:
 			// - The candidate doesn't have this alien at all.
 			// - The candidate does have this alien but with a different ID.
 			contentDiffs++
 			if VERBOSE {
-				fmt.Printf("Not found: expected: %+v actual: %+v\n", expectedAlien, actualAlien)
+				fmt.Printf("Not found: expected: %+v\n", match.expected)
 			}
+			continue
+		}
+
+		if VERBOSE {
+			fmt.Println()
+			fmt.Printf("Expected alien: %+v\n", match.expected)
+			fmt.Printf("Actual alien: %+v\n", *match.actual)
+			fmt.Println()
+		}
+
+		if !aliensEqual(match.expected, *match.actual) {
+			contentDiffs++
 		}
 	}
 
@@ -240,6 +329,99 @@ func CalculateAlienDistance(expected, actual []DetailedAlien) int {
 	return distance
 }
 
+// Returns the number of positions where the actual aliens are out of order, compared by the sort field
+// alone so that aliens tied on the field may come back in any order. Aliens missing from actual are
+// already counted by CalculateAlienDistance.
+func CalculateSortDistance(expected, actual []DetailedAlien, field string) int {
+	distance := 0
+	for idx := range min(len(expected), len(actual)) {
+		if alienFieldValue(expected[idx], field) != alienFieldValue(actual[idx], field) {
+			distance++
+		}
+	}
+	return distance
+}
+
+// An expected alien, and the actual alien with the same ID it was matched to (nil if there was none).
+type alienMatch struct {
+	expected DetailedAlien
+	actual   *DetailedAlien
+}
+
+// Pairs every expected alien with an actual alien with the same ID.
+type alienMatcher func(expected, actual []DetailedAlien) []alienMatch
+
+// Matches every expected alien to the last actual alien with the same ID, so duplicate IDs collapse into one.
+func collapseAliensByID(expected, actual []DetailedAlien) []alienMatch {
+	actualMap := make(map[string]DetailedAlien)
+	for _, alien := range actual {
+		actualMap[alien.ID] = alien
+	}
+
+	matches := make([]alienMatch, len(expected))
+	for idx, expectedAlien := range expected {
+		matches[idx].expected = expectedAlien
+		if actualAlien, exists := actualMap[expectedAlien.ID]; exists {
+			matches[idx].actual = &actualAlien
+		}
+	}
+	return matches
+}
+
+// Matches every expected alien to at most one actual alien with the same ID, preferring an identical one.
+// Generated IDs can collide, so an ID may appear more than once on either side.
+func matchAliensByID(expected, actual []DetailedAlien) []alienMatch {
+	unmatched := make(map[string][]DetailedAlien)
+	for _, alien := range actual {
+		unmatched[alien.ID] = append(unmatched[alien.ID], alien)
+	}
+
+	// Pair up identical aliens first, so a mismatched duplicate can't take an identical alien's match.
+	matches := make([]alienMatch, len(expected))
+	for idx, expectedAlien := range expected {
+		matches[idx].expected = expectedAlien
+		candidates := unmatched[expectedAlien.ID]
+		if candidateIdx := slices.IndexFunc(candidates, func(alien DetailedAlien) bool {
+			return aliensEqual(expectedAlien, alien)
+		}); candidateIdx != -1 {
+			matches[idx].actual = &candidates[candidateIdx]
+			unmatched[expectedAlien.ID] = slices.Delete(slices.Clone(candidates), candidateIdx, candidateIdx+1)
+		}
+	}
+	for idx := range matches {
+		candidates := unmatched[matches[idx].expected.ID]
+		if matches[idx].actual == nil && len(candidates) > 0 {
+			matches[idx].actual = &candidates[0]
+			unmatched[matches[idx].expected.ID] = candidates[1:]
+		}
+	}
+	return matches
+}
+
+// Returns up to sampleSize IDs that are missing from actual, unexpected in actual, and present in both but
+// with differing values, each in the order they appear.
+func sampleAlienDiff(expected, actual []DetailedAlien, matches []alienMatch, sampleSize int) (missing []string, unexpected []string, mismatched []string) {
+	expectedIDs := make(map[string]bool)
+	for _, alien := range expected {
+		expectedIDs[alien.ID] = true
+	}
+
+	missing, unexpected, mismatched = []string{}, []string{}, []string{}
+	for _, match := range matches {
+		if match.actual == nil && len(missing) < sampleSize {
+			missing = append(missing, match.expected.ID)
+		} else if match.actual != nil && !aliensEqual(match.expected, *match.actual) && len(mismatched) < sampleSize {
+			mismatched = append(mismatched, match.expected.ID)
+		}
+	}
+	for _, actualAlien := range actual {
+		if !expectedIDs[actualAlien.ID] && len(unexpected) < sampleSize {
+			unexpected = append(unexpected, actualAlien.ID)
+		}
+	}
+	return missing, unexpected, mismatched
+}
+
 func aliensEqual(a, b DetailedAlien) bool {
 	return a.ID == b.ID &&
 		a.FirstName == b.FirstName &&
@@ -403,19 +585,10 @@ func filterAliensByNumericField(aliens []DetailedAlien, field string, op Compari
 	var filtered []DetailedAlien
 
 	for _, alien := range aliens {
-		var fieldValue int
-
-		// Get the field value:
-		switch field {
-		case ATK:
-			fieldValue = alien.BaseAlien.Atk
-		case SPD:
-			fieldValue = alien.Spd
-		case HP:
-			fieldValue = alien.BaseAlien.Hp
-		default:
+		if !slices.Contains(numericFields, field) {
 			continue
 		}
+		fieldValue := alienFieldValue(alien, field)
 
 		// Apply comparison:
 		var matches bool
@@ -434,6 +607,21 @@ func filterAliensByNumericField(aliens []DetailedAlien, field string, op Compari
 	return filtered
 }
 
+var numericFields = []string{ATK, SPD, HP}
+
+// Returns the value of one of the numericFields, or 0 for any other field.
+func alienFieldValue(alien DetailedAlien, field string) int {
+	switch field {
+	case ATK:
+		return alien.BaseAlien.Atk
+	case SPD:
+		return alien.Spd
+	case HP:
+		return alien.BaseAlien.Hp
+	}
+	return 0
+}
+
 func health(ctx context.Context, client *http.Client, url string) (ok bool, err error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/healthcheck", nil)
 	if err != nil {
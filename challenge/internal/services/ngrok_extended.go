@@ -0,0 +1,294 @@
+package services
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"generate_technical_challenge_2025/internal/data"
+	"generate_technical_challenge_2025/internal/utils"
+	"math/rand"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NGROK_GET_ALIEN_POINTS    = 10
+	NGROK_PAGINATION_POINTS   = 10
+	NGROK_SORT_POINTS         = 15
+	NGROK_PATCH_POINTS        = 10
+	NGROK_DELETE_ALIEN_POINTS = 10
+	NGROK_PAGE_LOWER_BOUND    = 10
+	NGROK_PAGE_UPPER_BOUND    = 50
+	SORT_ASC                  = "asc"
+	SORT_DESC                 = "desc"
+)
+
+// GET /api/aliens/{id}, expects the single alien back.
+type NgrokGetAlienRequest struct {
+	Name          string
+	Points        int
+	Path          string
+	ExpectedAlien DetailedAlien
+}
+
+// PATCH /api/aliens/{id} with a partial alien, expects the updated alien back.
+type NgrokPatchRequest struct {
+	Name          string
+	Points        int
+	Path          string
+	Body          map[string]any
+	ExpectedAlien DetailedAlien
+}
+
+// DELETE /api/aliens/{id}, then GET /api/aliens/{id} to make sure the alien is gone.
+type NgrokDeleteAlienRequest struct {
+	Name   string
+	Points int
+	Path   string
+}
+
+func (t NgrokGetAlienRequest) GetName() string {
+	return t.Name
+}
+
+func (t NgrokPatchRequest) GetName() string {
+	return t.Name
+}
+
+func (t NgrokDeleteAlienRequest) GetName() string {
+	return t.Name
+}
+
+func (t NgrokGetAlienRequest) GetTotalPossiblePoints() int {
+	return t.Points
+}
+
+func (t NgrokPatchRequest) GetTotalPossiblePoints() int {
+	return t.Points
+}
+
+func (t NgrokDeleteAlienRequest) GetTotalPossiblePoints() int {
+	return t.Points
+}
+
+func (t NgrokGetAlienRequest) Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult {
+	result := newNgrokRequestResult(t, http.MethodGet, t.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+t.Path, nil)
+	if err != nil {
+		return result.fail(fmt.Errorf("failed to create request: %w", err))
+	}
+
+	resp, err := sendNgrokRequest(client, req, &result)
+	if err != nil {
+		return result.fail(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return result.fail(fmt.Errorf("expected status 200, got %d", resp.StatusCode))
+	}
+
+	var actualAlien DetailedAlien
+	if err := json.NewDecoder(resp.Body).Decode(&actualAlien); err != nil {
+		return result.fail(fmt.Errorf("invalid JSON response: %w", err))
+	}
+
+	result.compare([]DetailedAlien{t.ExpectedAlien}, []DetailedAlien{actualAlien}, matchAliensByID)
+	if result.Distance == 0 {
+		result.PointsEarned = t.Points
+	}
+	return result
+}
+
+func (t NgrokPatchRequest) Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult {
+	result := newNgrokRequestResult(t, http.MethodPatch, t.Path)
+
+	bodyBytes, err := json.Marshal(t.Body)
+	if err != nil {
+		return result.fail(fmt.Errorf("failed to marshal request body: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, baseURL+t.Path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return result.fail(fmt.Errorf("failed to create request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := sendNgrokRequest(client, req, &result)
+	if err != nil {
+		return result.fail(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return result.fail(fmt.Errorf("expected status 200, got %d", resp.StatusCode))
+	}
+
+	var actualAlien DetailedAlien
+	if err := json.NewDecoder(resp.Body).Decode(&actualAlien); err != nil {
+		return result.fail(fmt.Errorf("invalid JSON response: %w", err))
+	}
+
+	result.compare([]DetailedAlien{t.ExpectedAlien}, []DetailedAlien{actualAlien}, matchAliensByID)
+	if result.Distance == 0 {
+		result.PointsEarned = t.Points
+	}
+	return result
+}
+
+// Half of the points are for accepting the DELETE, the other half for the alien actually being gone.
+func (t NgrokDeleteAlienRequest) Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult {
+	result := newNgrokRequestResult(t, http.MethodDelete, t.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, baseURL+t.Path, nil)
+	if err != nil {
+		return result.fail(fmt.Errorf("failed to create request: %w", err))
+	}
+
+	resp, err := sendNgrokRequest(client, req, &result)
+	if err != nil {
+		return result.fail(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return result.fail(fmt.Errorf("expected status 204 or 200, got %d", resp.StatusCode))
+	}
+	result.PointsEarned = t.Points / 2
+
+	// Make sure the alien is actually gone, without overwriting the DELETE's status or latency.
+	verify, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+t.Path, nil)
+	if err != nil {
+		return result.fail(fmt.Errorf("failed to create request: %w", err))
+	}
+	verifyResult := result
+	verifyResp, err := sendNgrokRequest(client, verify, &verifyResult)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	verifyResp.Body.Close()
+
+	if verifyResp.StatusCode != http.StatusNotFound {
+		result.Err = fmt.Errorf("expected status 404 after deleting, got %d", verifyResp.StatusCode)
+		return result
+	}
+	result.PointsEarned = t.Points
+	return result
+}
+
+// Generates a deterministic set of aliens like GenerateNgrokAliens, except every ID is unique.
+// The requests below look aliens up by ID, which is ambiguous when two aliens share one.
+func GenerateUniqueNgrokAliens(rng *rand.Rand, memberID uuid.UUID) []DetailedAlien {
+	count := utils.GenerateRandomNumWithinRange(rng, NUM_NGROK_ALIENS_LOWER_BOUND, NUM_NGROK_ALIENS_UPPER_BOUND)
+	aliens := []DetailedAlien{}
+	seenIDs := map[string]bool{}
+	for alienIdx := 0; len(aliens) < count; alienIdx++ {
+		alien := GenerateDetailedAlien(rng, memberID, alienIdx)
+		if seenIDs[alien.ID] {
+			continue
+		}
+		seenIDs[alien.ID] = true
+		aliens = append(aliens, alien)
+	}
+	return aliens
+}
+
+// Generates the read requests:
+// - GET a single random alien by ID.
+// - GET a random page with limit/offset.
+// - GET all aliens sorted by a random field and order.
+//
+// And the update requests, which must run after every request that expects the posted aliens, in this order:
+// - PATCH a random alien.
+// - DELETE a different alien.
+func GenerateExtendedTests(rng *rand.Rand, aliens []DetailedAlien) (reads []NgrokRequest, updates []NgrokRequest) {
+	// Test 1: Get a single alien.
+	reads = append(reads, generateGetAlienTest(rng, aliens))
+
+	// Test 2: Paginate in the order the aliens were posted.
+	reads = append(reads, generatePaginationTest(rng, aliens))
+
+	// Test 3: Sort by a random field and order.
+	reads = append(reads, generateSortTest(rng, aliens))
+
+	// Test 4 and 5: Patch and delete two different aliens.
+	patchIdx := rng.Intn(len(aliens))
+	deleteIdx := (patchIdx + 1 + rng.Intn(len(aliens)-1)) % len(aliens)
+	updates = append(updates, generatePatchTest(rng, aliens[patchIdx]))
+	updates = append(updates, generateDeleteAlienTest(aliens[deleteIdx]))
+
+	return reads, updates
+}
+
+func generateGetAlienTest(rng *rand.Rand, aliens []DetailedAlien) NgrokRequest {
+	alien := aliens[rng.Intn(len(aliens))]
+	return NgrokGetAlienRequest{
+		Name:          fmt.Sprintf("GET alien %s", alien.ID),
+		Points:        NGROK_GET_ALIEN_POINTS,
+		Path:          NGROK_PATH + "/" + alien.ID,
+		ExpectedAlien: alien,
+	}
+}
+
+func generatePaginationTest(rng *rand.Rand, aliens []DetailedAlien) NgrokRequest {
+	limit := utils.GenerateRandomNumWithinRange(rng, NGROK_PAGE_LOWER_BOUND, NGROK_PAGE_UPPER_BOUND)
+	offset := rng.Intn(len(aliens) - limit)
+	return NgrokGetRequest{
+		Name:           fmt.Sprintf("Paginate limit=%d offset=%d", limit, offset),
+		Points:         NGROK_PAGINATION_POINTS,
+		Path:           fmt.Sprintf("%s?limit=%d&offset=%d", NGROK_PATH, limit, offset),
+		ExpectedAliens: slices.Clone(aliens[offset : offset+limit]),
+	}
+}
+
+func generateSortTest(rng *rand.Rand, aliens []DetailedAlien) NgrokRequest {
+	field := numericFields[rng.Intn(len(numericFields))]
+	order := []string{SORT_ASC, SORT_DESC}[rng.Intn(2)]
+
+	expectedAliens := slices.Clone(aliens)
+	slices.SortStableFunc(expectedAliens, func(a, b DetailedAlien) int {
+		if order == SORT_DESC {
+			return cmp.Compare(alienFieldValue(b, field), alienFieldValue(a, field))
+		}
+		return cmp.Compare(alienFieldValue(a, field), alienFieldValue(b, field))
+	})
+
+	return NgrokGetRequest{
+		Name:           fmt.Sprintf("Sort by %s %s", field, order),
+		Points:         NGROK_SORT_POINTS,
+		Path:           fmt.Sprintf("%s?sort=%s&order=%s", NGROK_PATH, field, order),
+		ExpectedAliens: expectedAliens,
+		SortField:      field,
+	}
+}
+
+func generatePatchTest(rng *rand.Rand, alien DetailedAlien) NgrokRequest {
+	firstName := data.AlienFirstNames[rng.Intn(len(data.AlienFirstNames))]
+	spd := utils.GenerateRandomNumWithinRange(rng, ALIEN_ATK_HP_SPD_LOWER, ALIEN_ATK_HP_SPD_UPPER)
+
+	expectedAlien := alien
+	expectedAlien.FirstName = firstName
+	expectedAlien.Spd = spd
+
+	return NgrokPatchRequest{
+		Name:          fmt.Sprintf("PATCH alien %s", alien.ID),
+		Points:        NGROK_PATCH_POINTS,
+		Path:          NGROK_PATH + "/" + alien.ID,
+		Body:          map[string]any{"first_name": firstName, "spd": spd},
+		ExpectedAlien: expectedAlien,
+	}
+}
+
+func generateDeleteAlienTest(alien DetailedAlien) NgrokRequest {
+	return NgrokDeleteAlienRequest{
+		Name:   fmt.Sprintf("DELETE alien %s", alien.ID),
+		Points: NGROK_DELETE_ALIEN_POINTS,
+		Path:   NGROK_PATH + "/" + alien.ID,
+	}
+}
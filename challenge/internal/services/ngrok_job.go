@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"generate_technical_challenge_2025/internal/database/models"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+const (
+	NGROK_JOB_QUEUE_SIZE = 100
+	// Message recorded on jobs that were running when the server stopped, grading them again could
+	// double count a member's attempt.
+	NGROK_JOB_INTERRUPTED_MESSAGE = "Grading was interrupted by a server restart, please resubmit."
+	NGROK_JOB_QUEUE_FULL_MESSAGE  = "Grading queue is full, please try again later."
+	NGROK_HEALTH_CHECK_MESSAGE    = "Health check failed--server unreachable"
+	NGROK_SCORED_MESSAGE          = "Submission has been been successfully scored."
+)
+
+var ErrNgrokQueueFull = errors.New("ngrok grading queue is full")
+
+// SubmitNgrokJob implements ChallengeService.
+// Persists a pending grading job for the member's server and queues it for the workers.
+func (c ChallengeServiceImpl) SubmitNgrokJob(memberID uuid.UUID, version int, serverURL url.URL) (*models.NgrokJob, error) {
+	job := models.CreateNgrokJob(memberID, serverURL.String(), version)
+	if err := c.transactions.InsertNgrokJob(job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case c.ngrokJobs <- job.ID:
+		return job, nil
+	default:
+		// Never leave the job pending, otherwise it would be picked up again on restart.
+		if err := c.transactions.UpdateNgrokJobStatus(job.ID, models.NGROK_JOB_FAILED, NGROK_JOB_QUEUE_FULL_MESSAGE); err != nil {
+			c.logger.Error("Failed to mark ngrok job as failed.", "job", job.ID, "error", err)
+		}
+		return nil, ErrNgrokQueueFull
+	}
+}
+
+// GetNgrokJob implements ChallengeService.
+// Returns the job and whether it exists for the given member.
+func (c ChallengeServiceImpl) GetNgrokJob(memberID uuid.UUID, jobID uuid.UUID) (*models.NgrokJob, bool, error) {
+	job, exists, err := c.transactions.GetNgrokJob(jobID)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	if job.UserID != memberID {
+		return nil, false, nil
+	}
+	return job, true, nil
+}
+
+// StartNgrokWorkers implements ChallengeService.
+// Fails jobs that were interrupted by the last shutdown and refunds their attempts through members, resumes
+// the pending ones, and then grades queued jobs on the given number of workers until the context is cancelled.
+func (c ChallengeServiceImpl) StartNgrokWorkers(ctx context.Context, workers int, members MemberService) error {
+	interrupted, err := c.transactions.GetNgrokJobsByStatus(models.NGROK_JOB_RUNNING)
+	if err != nil {
+		return err
+	}
+	for _, job := range interrupted {
+		if err := c.transactions.UpdateNgrokJobStatus(job.ID, models.NGROK_JOB_FAILED, NGROK_JOB_INTERRUPTED_MESSAGE); err != nil {
+			return err
+		}
+		// The job was never graded, so it should not count against the member.
+		if err := members.RefundAttempt(job.UserID, models.NGROK_CHALLENGE_TYPE); err != nil {
+			c.logger.Error("Failed to refund interrupted ngrok attempt.", "job", job.ID, "member", job.UserID, "error", err)
+		}
+	}
+
+	pending, err := c.transactions.GetNgrokJobsByStatus(models.NGROK_JOB_PENDING)
+	if err != nil {
+		return err
+	}
+	c.logger.Info("Starting ngrok workers.", "workers", workers, "interrupted", len(interrupted), "pending", len(pending))
+
+	for range workers {
+		go c.runNgrokWorker(ctx)
+	}
+
+	// There may be more pending jobs than the queue holds, so wait for the workers to make room.
+	go func() {
+		for _, job := range pending {
+			select {
+			case c.ngrokJobs <- job.ID:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (c ChallengeServiceImpl) runNgrokWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case jobID := <-c.ngrokJobs:
+			c.runNgrokJob(ctx, jobID)
+		}
+	}
+}
+
+func (c ChallengeServiceImpl) runNgrokJob(ctx context.Context, jobID uuid.UUID) {
+	job, exists, err := c.transactions.GetNgrokJob(jobID)
+	if err != nil || !exists {
+		c.logger.Error("Failed to load ngrok job.", "job", jobID, "error", err)
+		return
+	}
+	if job.Status != models.NGROK_JOB_PENDING {
+		return
+	}
+	if err := c.transactions.UpdateNgrokJobStatus(job.ID, models.NGROK_JOB_RUNNING, ""); err != nil {
+		c.logger.Error("Failed to mark ngrok job as running.", "job", job.ID, "error", err)
+		return
+	}
+
+	gradeResult := c.gradeNgrokJob(ctx, job)
+
+	scoreValue := models.INVALID_SCORE
+	if gradeResult.Valid {
+		scoreValue = gradeResult.Score
+	}
+	score := models.CreateScore(job.UserID, models.NGROK_CHALLENGE_TYPE, scoreValue, gradeResult.Valid, job.ChallengeVersion)
+	if gradeResult.Valid {
+		score.LatencyP50Ms = lo.ToPtr(gradeResult.Latency.P50.Milliseconds())
+		score.LatencyP95Ms = lo.ToPtr(gradeResult.Latency.P95.Milliseconds())
+	}
+
+	job.Status = models.NGROK_JOB_DONE
+	job.Message = NGROK_SCORED_MESSAGE
+	if !gradeResult.Valid {
+		job.Message = gradeResult.Reason
+	}
+	job.Checks = lo.Map(gradeResult.Results, func(result NgrokRequestResult, _ int) models.NgrokJobCheck {
+		return result.toJobCheck()
+	})
+	job.LatencyP50Ms = gradeResult.Latency.P50.Milliseconds()
+	job.LatencyP95Ms = gradeResult.Latency.P95.Milliseconds()
+	job.LatencyPoints = gradeResult.Latency.Points
+
+	if err := c.transactions.CompleteNgrokJob(job, score); err != nil {
+		c.logger.Error("Failed to save ngrok job score.", "job", job.ID, "error", err)
+		if err := c.transactions.UpdateNgrokJobStatus(job.ID, models.NGROK_JOB_FAILED, "Database error when saving a score."); err != nil {
+			c.logger.Error("Failed to mark ngrok job as failed.", "job", job.ID, "error", err)
+		}
+	}
+}
+
+func (c ChallengeServiceImpl) gradeNgrokJob(ctx context.Context, job *models.NgrokJob) NgrokChallengeScore {
+	serverURL, err := url.Parse(job.URL)
+	if err != nil {
+		return NgrokChallengeScore{Valid: false, Reason: "Submitted URL could not be parsed."}
+	}
+
+	ok, err := c.HealthCheck(ctx, *serverURL)
+	if err != nil || !ok {
+		return NgrokChallengeScore{Valid: false, Reason: NGROK_HEALTH_CHECK_MESSAGE}
+	}
+
+	requests := c.GenerateUniqueNgrokChallenge(job.UserID, job.ChallengeVersion)
+	return c.GradeNgrokServer(ctx, *serverURL, requests)
+}
+
+func (r NgrokRequestResult) toJobCheck() models.NgrokJobCheck {
+	check := models.NgrokJobCheck{
+		Name:           r.Name,
+		Method:         r.Method,
+		Path:           r.Path,
+		PointsEarned:   r.PointsEarned,
+		PointsPossible: r.PointsPossible,
+		StatusCode:     r.StatusCode,
+		LatencyMs:      r.Latency.Milliseconds(),
+		Compared:       r.Compared,
+		ExpectedCount:  r.ExpectedCount,
+		ActualCount:    r.ActualCount,
+		Distance:       r.Distance,
+		MissingIDs:     r.MissingIDs,
+		UnexpectedIDs:  r.UnexpectedIDs,
+		MismatchedIDs:  r.MismatchedIDs,
+	}
+	if r.Err != nil {
+		check.Error = r.Err.Error()
+	}
+	return check
+}
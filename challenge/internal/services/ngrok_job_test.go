@@ -0,0 +1,213 @@
+package services_test
+
+import (
+	"context"
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/reference"
+	"generate_technical_challenge_2025/internal/services"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// In memory stand in for the ngrok job tables.
+type fakeNgrokJobTransactions struct {
+	mu     sync.Mutex
+	jobs   map[uuid.UUID]models.NgrokJob
+	scores map[uuid.UUID]models.Score
+}
+
+// Records every refunded attempt.
+type refundRecorder struct {
+	services.MemberService
+	members []uuid.UUID
+}
+
+func (r *refundRecorder) RefundAttempt(memberID uuid.UUID, challengeType string) error {
+	if challengeType == models.NGROK_CHALLENGE_TYPE {
+		r.members = append(r.members, memberID)
+	}
+	return nil
+}
+
+func createFakeNgrokJobTransactions() *fakeNgrokJobTransactions {
+	return &fakeNgrokJobTransactions{jobs: map[uuid.UUID]models.NgrokJob{}, scores: map[uuid.UUID]models.Score{}}
+}
+
+func (f *fakeNgrokJobTransactions) SaveAlienChallengeSolutionsForMember(*models.Score, *models.Submission) error {
+	return nil
+}
+
+func (f *fakeNgrokJobTransactions) InsertNgrokJob(job *models.NgrokJob) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[job.ID] = *job
+	return nil
+}
+
+func (f *fakeNgrokJobTransactions) GetNgrokJob(id uuid.UUID) (*models.NgrokJob, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job, ok := f.jobs[id]
+	if !ok {
+		return nil, false, nil
+	}
+	if job.ScoreID != nil {
+		score := f.scores[*job.ScoreID]
+		job.Score = &score
+	}
+	return &job, true, nil
+}
+
+func (f *fakeNgrokJobTransactions) GetNgrokJobsByStatus(status string) ([]models.NgrokJob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	jobs := []models.NgrokJob{}
+	for _, job := range f.jobs {
+		if job.Status == status {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (f *fakeNgrokJobTransactions) UpdateNgrokJobStatus(id uuid.UUID, status string, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job := f.jobs[id]
+	job.Status = status
+	job.Message = message
+	f.jobs[id] = job
+	return nil
+}
+
+func (f *fakeNgrokJobTransactions) CompleteNgrokJob(job *models.NgrokJob, score *models.Score) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.scores[score.ID] = *score
+	job.ScoreID = &score.ID
+	f.jobs[job.ID] = *job
+	return nil
+}
+
+func waitForNgrokJob(t *testing.T, service services.ChallengeService, memberID uuid.UUID, jobID uuid.UUID) *models.NgrokJob {
+	var job *models.NgrokJob
+	require.Eventually(t, func() bool {
+		found, exists, err := service.GetNgrokJob(memberID, jobID)
+		require.NoError(t, err)
+		require.True(t, exists)
+		job = found
+		return job.Status == models.NGROK_JOB_DONE || job.Status == models.NGROK_JOB_FAILED
+	}, 30*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestNgrokJobIsGradedInTheBackground(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := services.CreateChallengeService(LOGGER, createFakeNgrokJobTransactions(), services.NgrokLatencyConfig{}, true)
+	require.NoError(t, service.StartNgrokWorkers(ctx, 2, &refundRecorder{}))
+
+	job, err := service.SubmitNgrokJob(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION, *serverURL)
+	require.NoError(t, err)
+	assert.Equal(t, models.NGROK_JOB_PENDING, job.Status)
+
+	// Jobs are only visible to the member that submitted them.
+	_, exists, err := service.GetNgrokJob(uuid.New(), job.ID)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	done := waitForNgrokJob(t, service, NGROK_UUID, job.ID)
+	challenge := service.GenerateUniqueNgrokChallenge(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION)
+	expected := service.GradeNgrokServer(context.Background(), *serverURL, challenge)
+
+	assert.Equal(t, models.NGROK_JOB_DONE, done.Status)
+	assert.Equal(t, services.NGROK_SCORED_MESSAGE, done.Message)
+	require.NotNil(t, done.Score)
+	assert.True(t, done.Score.IsValid)
+	assert.Equal(t, expected.Score, done.Score.Score)
+	assert.Equal(t, models.NGROK_CHALLENGE_TYPE, done.Score.ChallengeType)
+	assert.Zero(t, done.LatencyPoints)
+	// The latency is kept on the score too, for the score history.
+	assert.Equal(t, &done.LatencyP50Ms, done.Score.LatencyP50Ms)
+	assert.Equal(t, &done.LatencyP95Ms, done.Score.LatencyP95Ms)
+	assert.Len(t, done.Checks, len(challenge.Requests))
+}
+
+func TestNgrokJobRecordsFailedHealthCheck(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := services.CreateChallengeService(LOGGER, createFakeNgrokJobTransactions(), services.NgrokLatencyConfig{}, true)
+	require.NoError(t, service.StartNgrokWorkers(ctx, 1, &refundRecorder{}))
+
+	job, err := service.SubmitNgrokJob(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION, *serverURL)
+	require.NoError(t, err)
+
+	done := waitForNgrokJob(t, service, NGROK_UUID, job.ID)
+	assert.Equal(t, models.NGROK_JOB_DONE, done.Status)
+	assert.Equal(t, services.NGROK_HEALTH_CHECK_MESSAGE, done.Message)
+	require.NotNil(t, done.Score)
+	assert.False(t, done.Score.IsValid)
+	assert.Equal(t, models.INVALID_SCORE, done.Score.Score)
+	assert.Empty(t, done.Checks)
+}
+
+func TestNgrokWorkersResumePendingAndFailInterruptedJobs(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	defer server.Close()
+
+	transactions := createFakeNgrokJobTransactions()
+	pending := models.CreateNgrokJob(NGROK_UUID, server.URL, services.CURRENT_CHALLENGE_VERSION)
+	interrupted := models.CreateNgrokJob(NGROK_UUID, server.URL, services.CURRENT_CHALLENGE_VERSION)
+	interrupted.Status = models.NGROK_JOB_RUNNING
+	require.NoError(t, transactions.InsertNgrokJob(pending))
+	require.NoError(t, transactions.InsertNgrokJob(interrupted))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := services.CreateChallengeService(LOGGER, transactions, services.NgrokLatencyConfig{}, true)
+	refunds := &refundRecorder{}
+	require.NoError(t, service.StartNgrokWorkers(ctx, 1, refunds))
+	// Only the interrupted job was refunded, the pending one is still graded.
+	assert.Equal(t, []uuid.UUID{interrupted.UserID}, refunds.members)
+
+	failed := waitForNgrokJob(t, service, NGROK_UUID, interrupted.ID)
+	assert.Equal(t, models.NGROK_JOB_FAILED, failed.Status)
+	assert.Equal(t, services.NGROK_JOB_INTERRUPTED_MESSAGE, failed.Message)
+	assert.Nil(t, failed.Score)
+
+	resumed := waitForNgrokJob(t, service, NGROK_UUID, pending.ID)
+	assert.Equal(t, models.NGROK_JOB_DONE, resumed.Status)
+	require.NotNil(t, resumed.Score)
+	assert.True(t, resumed.Score.IsValid)
+}
+
+func TestSubmitNgrokJobFailsWhenQueueIsFull(t *testing.T) {
+	serverURL, err := url.Parse("http://localhost:1")
+	require.NoError(t, err)
+
+	// No workers are started, so nothing drains the queue.
+	service := services.CreateChallengeService(LOGGER, createFakeNgrokJobTransactions(), services.NgrokLatencyConfig{}, true)
+	for range services.NGROK_JOB_QUEUE_SIZE {
+		_, err := service.SubmitNgrokJob(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION, *serverURL)
+		require.NoError(t, err)
+	}
+
+	_, err = service.SubmitNgrokJob(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION, *serverURL)
+	assert.ErrorIs(t, err, services.ErrNgrokQueueFull)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"generate_technical_challenge_2025/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// Time allowed for repeating requests to measure latency, so that a slow server still has time left for
+// the requests after them.
+const NGROK_LATENCY_BUDGET = 5 * time.Second
+
+// First challenge version scored with the latency bonus or penalty. Older versions are still measured, but keep
+// the scores they were graded with before, so that they can be regraded and compared.
+const NGROK_LATENCY_SCORING_VERSION = 5
+
+// Latency settings used when the environment does not set them, see CreateNgrokLatencyConfig.
+const (
+	NGROK_DEFAULT_LATENCY_SAMPLES = 3
+	NGROK_DEFAULT_FAST_P95        = 250 * time.Millisecond
+	NGROK_DEFAULT_SLOW_P95        = 2 * time.Second
+	NGROK_DEFAULT_LATENCY_BONUS   = 5
+	NGROK_DEFAULT_LATENCY_PENALTY = 10
+)
+
+// Configures how the latency of a candidate's server affects their ngrok score. The zero value only
+// measures the latency of the graded requests, without changing the score.
+type NgrokLatencyConfig struct {
+	Samples int           // Number of extra times every read only request is sent, one at a time.
+	FastP95 time.Duration // Bonus points are earned at or under this p95, disabled when zero.
+	SlowP95 time.Duration // Penalty points are lost over this p95, disabled when zero.
+	Bonus   int
+	Penalty int
+}
+
+// Latency of the read only requests sent to the candidate's server.
+type NgrokLatency struct {
+	P50     time.Duration
+	P95     time.Duration
+	Samples int // Number of responses the percentiles were taken from.
+	Points  int // Bonus (positive) or penalty (negative) applied to the score.
+}
+
+// Uses the defaults for every setting the environment leaves out.
+func CreateNgrokLatencyConfig(env utils.EnvConfig) NgrokLatencyConfig {
+	return NgrokLatencyConfig{
+		Samples: lo.FromPtrOr(env.NGROK_LATENCY_SAMPLES, NGROK_DEFAULT_LATENCY_SAMPLES),
+		FastP95: lo.FromPtrOr(env.NGROK_FAST_P95, NGROK_DEFAULT_FAST_P95),
+		SlowP95: lo.FromPtrOr(env.NGROK_SLOW_P95, NGROK_DEFAULT_SLOW_P95),
+		Bonus:   lo.FromPtrOr(env.NGROK_LATENCY_BONUS, NGROK_DEFAULT_LATENCY_BONUS),
+		Penalty: lo.FromPtrOr(env.NGROK_LATENCY_PENALTY, NGROK_DEFAULT_LATENCY_PENALTY),
+	}
+}
+
+// Takes the latency of every read only request that got a response, after sending each of them
+// c.latency.Samples more times. Only challenges since NGROK_LATENCY_SCORING_VERSION earn or lose points for it.
+func (c ChallengeServiceImpl) measureNgrokLatency(ctx context.Context, baseURL string, version int, readRequests []NgrokRequest, readResults []NgrokRequestResult) NgrokLatency {
+	ctx, cancel := context.WithTimeout(ctx, NGROK_LATENCY_BUDGET)
+	defer cancel()
+
+	latencies := []time.Duration{}
+	record := func(result NgrokRequestResult) {
+		if result.StatusCode != 0 {
+			latencies = append(latencies, result.Latency)
+		}
+	}
+	for _, result := range readResults {
+		record(result)
+	}
+
+	// Sent one at a time, so that the latency is the server's and not how it handles concurrent requests.
+	for range c.latency.Samples {
+		for _, request := range readRequests {
+			if ctx.Err() != nil {
+				break
+			}
+			record(request.Execute(ctx, c.customClient, baseURL))
+		}
+	}
+
+	latency := NgrokLatency{
+		P50:     percentile(latencies, 50),
+		P95:     percentile(latencies, 95),
+		Samples: len(latencies),
+	}
+	if version >= NGROK_LATENCY_SCORING_VERSION {
+		latency.Points = c.latency.points(latency)
+	}
+	return latency
+}
+
+func (config NgrokLatencyConfig) points(latency NgrokLatency) int {
+	switch {
+	case latency.Samples == 0:
+		return 0
+	case config.SlowP95 > 0 && latency.P95 > config.SlowP95:
+		return -config.Penalty
+	case config.FastP95 > 0 && latency.P95 <= config.FastP95:
+		return config.Bonus
+	}
+	return 0
+}
+
+// Nearest rank percentile, 0 when there are no latencies.
+func percentile(latencies []time.Duration, p int) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"context"
+	"generate_technical_challenge_2025/internal/reference"
+	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/transactions"
+	"generate_technical_challenge_2025/internal/utils"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sethvargo/go-envconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createLatencyService(config services.NgrokLatencyConfig) services.ChallengeService {
+	return services.CreateChallengeService(LOGGER, transactions.CreateChallengeTransactions(LOGGER, nil), config, true)
+}
+
+// Forwards every request to the given server after the given delay.
+func createDelayedServer(t *testing.T, server *httptest.Server, delay time.Duration) *url.URL {
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	proxy := httputil.NewSingleHostReverseProxy(serverURL)
+	delayed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		proxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(delayed.Close)
+	delayedURL, err := url.Parse(delayed.URL)
+	require.NoError(t, err)
+	return delayedURL
+}
+
+func countReadRequests(challenge services.NgrokChallenge) int {
+	return lo.CountBy(challenge.Requests, func(request services.NgrokRequest) bool {
+		switch request.(type) {
+		case services.NgrokGetRequest, services.NgrokGetAlienRequest, services.NgrokMalformedQueryRequest:
+			return true
+		}
+		return false
+	})
+}
+
+func TestGradeNgrokServerMeasuresLatencyWithoutScoringByDefault(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	defer server.Close()
+	serverURL := createDelayedServer(t, server, 5*time.Millisecond)
+
+	challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, 1)
+	score := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *serverURL, challenge)
+	assert.True(t, score.Valid)
+	assert.Equal(t, countReadRequests(challenge), score.Latency.Samples)
+	assert.GreaterOrEqual(t, score.Latency.P50, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, score.Latency.P95, score.Latency.P50)
+	assert.Zero(t, score.Latency.Points)
+}
+
+func TestGradeNgrokServerPenalizesSlowServer(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	defer server.Close()
+	serverURL := createDelayedServer(t, server, 20*time.Millisecond)
+	challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, services.NGROK_LATENCY_SCORING_VERSION)
+	baseline := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *serverURL, challenge)
+
+	service := createLatencyService(services.NgrokLatencyConfig{
+		Samples: 1, FastP95: time.Millisecond, SlowP95: 10 * time.Millisecond, Bonus: 5, Penalty: 10,
+	})
+	score := service.GradeNgrokServer(context.Background(), *serverURL, challenge)
+	assert.True(t, score.Valid)
+	// Every read is sent once to be graded, and once more to measure latency.
+	assert.Equal(t, 2*countReadRequests(challenge), score.Latency.Samples)
+	assert.Greater(t, score.Latency.P95, 10*time.Millisecond)
+	assert.Equal(t, -10, score.Latency.Points)
+	assert.Equal(t, baseline.Score+10, score.Score)
+}
+
+func TestGradeNgrokServerRewardsFastServer(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, services.NGROK_LATENCY_SCORING_VERSION)
+	baseline := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *serverURL, challenge)
+	require.Greater(t, baseline.Score, 5)
+
+	service := createLatencyService(services.NgrokLatencyConfig{
+		FastP95: time.Second, SlowP95: 2 * time.Second, Bonus: 5, Penalty: 10,
+	})
+	score := service.GradeNgrokServer(context.Background(), *serverURL, challenge)
+	assert.Equal(t, 5, score.Latency.Points)
+	assert.Equal(t, baseline.Score-5, score.Score)
+
+	// The bonus only makes up for lost points.
+	compliant := reference.CreateServer(reference.Faults{})
+	defer compliant.Close()
+	compliantURL, err := url.Parse(compliant.URL)
+	require.NoError(t, err)
+	challenge = CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION)
+	score = service.GradeNgrokServer(context.Background(), *compliantURL, challenge)
+	assert.Equal(t, 5, score.Latency.Points)
+	assert.Zero(t, score.Score)
+}
+
+// Scores of versions from before latency was scored must not change when they are regraded.
+func TestGradeNgrokServerOnlyScoresLatencySinceItsVersion(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	service := createLatencyService(services.NgrokLatencyConfig{FastP95: time.Second, Bonus: 5})
+
+	challenge := service.GenerateUniqueNgrokChallenge(NGROK_UUID, 1)
+	baseline := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *serverURL, challenge)
+	score := service.GradeNgrokServer(context.Background(), *serverURL, challenge)
+	assert.Positive(t, score.Latency.Samples)
+	assert.Zero(t, score.Latency.Points)
+	assert.Equal(t, baseline.Score, score.Score)
+}
+
+func TestCreateNgrokLatencyConfigFillsInDefaults(t *testing.T) {
+	lookuper := envconfig.MapLookuper(map[string]string{
+		"DB_HOST": "localhost", "DB_PORT": "5432", "DB_USER": "user", "DB_PASSWORD": "password", "DB_NAME": "db",
+		"SLACK_WEBHOOK": "", "NGROK_LATENCY_SAMPLES": "0", "NGROK_SLOW_P95": "1s",
+	})
+	var env utils.EnvConfig
+	require.NoError(t, envconfig.ProcessWith(context.Background(), &envconfig.Config{Target: &env, Lookuper: lookuper}))
+
+	// Settings can still be turned off with 0.
+	assert.Equal(t, services.NgrokLatencyConfig{
+		Samples: 0,
+		FastP95: services.NGROK_DEFAULT_FAST_P95,
+		SlowP95: time.Second,
+		Bonus:   services.NGROK_DEFAULT_LATENCY_BONUS,
+		Penalty: services.NGROK_DEFAULT_LATENCY_PENALTY,
+	}, services.CreateNgrokLatencyConfig(env))
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"generate_technical_challenge_2025/internal/data"
+	"generate_technical_challenge_2025/internal/utils"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	QUERY_TYPE   = "type"
+	QUERY_LIMIT  = "limit"
+	QUERY_OFFSET = "offset"
+	QUERY_SORT   = "sort"
+	QUERY_ORDER  = "order"
+)
+
+// Candidates should respond to a malformed query with a 400.
+var ErrMalformedQuery = errors.New("malformed query")
+
+// Every query parameter a candidate's GET /api/aliens supports. Anything else should be ignored.
+func supportedQueryParameters() []string {
+	return append(filterParameters(), QUERY_LIMIT, QUERY_OFFSET, QUERY_SORT, QUERY_ORDER)
+}
+
+// Evaluates a GET /api/aliens query string against the posted aliens, the way a candidate's server should:
+//   - Every filter must hold (they are ANDed together).
+//   - Results are sorted by sort= and order= (asc by default), ties keep the order the aliens were posted in.
+//   - Then offset= aliens are skipped, and at most limit= are returned.
+//   - Unknown parameters are ignored.
+//
+// Returns ErrMalformedQuery when a supported parameter is repeated, or has a value that is not allowed.
+func EvaluateAlienQuery(aliens []DetailedAlien, rawQuery string) ([]DetailedAlien, error) {
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedQuery, err)
+	}
+
+	values := map[string]string{}
+	for _, parameter := range supportedQueryParameters() {
+		switch len(query[parameter]) {
+		case 0:
+			continue
+		case 1:
+			values[parameter] = query.Get(parameter)
+		default:
+			return nil, fmt.Errorf("%w: %s given more than once", ErrMalformedQuery, parameter)
+		}
+	}
+
+	result := slices.Clone(aliens)
+
+	if alienType, ok := values[QUERY_TYPE]; ok {
+		if !slices.Contains(alienTypes, AlienType(alienType)) {
+			return nil, fmt.Errorf("%w: unknown type %q", ErrMalformedQuery, alienType)
+		}
+		result = filterAliensByType(result, alienType)
+	}
+
+	for _, field := range numericFields {
+		for _, op := range []ComparisonOp{LTE, GTE} {
+			value, ok := values[field+"_"+string(op)]
+			if !ok {
+				continue
+			}
+			if _, err := strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("%w: %s_%s must be an integer", ErrMalformedQuery, field, op)
+			}
+			result = filterAliensByNumericField(result, field, op, value)
+		}
+	}
+
+	if field, ok := values[QUERY_SORT]; ok {
+		if !slices.Contains(numericFields, field) {
+			return nil, fmt.Errorf("%w: cannot sort by %q", ErrMalformedQuery, field)
+		}
+		order := SORT_ASC
+		if value, ok := values[QUERY_ORDER]; ok {
+			order = value
+		}
+		if order != SORT_ASC && order != SORT_DESC {
+			return nil, fmt.Errorf("%w: unknown order %q", ErrMalformedQuery, order)
+		}
+		slices.SortStableFunc(result, func(a, b DetailedAlien) int {
+			if order == SORT_DESC {
+				return cmp.Compare(alienFieldValue(b, field), alienFieldValue(a, field))
+			}
+			return cmp.Compare(alienFieldValue(a, field), alienFieldValue(b, field))
+		})
+	}
+
+	offset, err := nonNegativeQueryValue(values, QUERY_OFFSET, 0)
+	if err != nil {
+		return nil, err
+	}
+	result = result[min(offset, len(result)):]
+
+	limit, err := nonNegativeQueryValue(values, QUERY_LIMIT, len(result))
+	if err != nil {
+		return nil, err
+	}
+	result = result[:min(limit, len(result))]
+
+	// Filters return nil when nothing matches, but candidates should respond with an empty array.
+	if result == nil {
+		result = []DetailedAlien{}
+	}
+	return result, nil
+}
+
+func nonNegativeQueryValue(values map[string]string, parameter string, fallback int) (int, error) {
+	value, ok := values[parameter]
+	if !ok {
+		return fallback, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("%w: %s must be a non-negative integer", ErrMalformedQuery, parameter)
+	}
+	return parsed, nil
+}
+
+const (
+	NGROK_COMPOUND_FILTER_COUNT  = 3
+	NGROK_COMPOUND_FILTER_POINTS = 15
+	NGROK_IGNORED_QUERY_POINTS   = 5
+	NGROK_MALFORMED_QUERY_POINTS = 5
+)
+
+// GET /api/aliens with a malformed query, expects a 400.
+type NgrokMalformedQueryRequest struct {
+	Name   string
+	Points int
+	Path   string
+}
+
+func (t NgrokMalformedQueryRequest) GetName() string {
+	return t.Name
+}
+
+func (t NgrokMalformedQueryRequest) GetTotalPossiblePoints() int {
+	return t.Points
+}
+
+func (t NgrokMalformedQueryRequest) Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult {
+	result := newNgrokRequestResult(t, http.MethodGet, t.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+t.Path, nil)
+	if err != nil {
+		return result.fail(fmt.Errorf("failed to create request: %w", err))
+	}
+
+	resp, err := sendNgrokRequest(client, req, &result)
+	if err != nil {
+		return result.fail(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		return result.fail(fmt.Errorf("expected status 400, got %d", resp.StatusCode))
+	}
+
+	result.PointsEarned = t.Points
+	return result
+}
+
+// Generates:
+// - NGROK_COMPOUND_FILTER_COUNT GETs combining 2 to 4 random filters.
+// - GET with a filter and an unknown parameter, which should be ignored.
+// - GET with a filter given twice, which should be a 400.
+// - GET with a malformed value, which should be a 400.
+func GenerateCompoundFilterTests(rng *rand.Rand, aliens []DetailedAlien) []NgrokRequest {
+	requests := []NgrokRequest{}
+
+	for range NGROK_COMPOUND_FILTER_COUNT {
+		requests = append(requests, generateCompoundFilterTest(rng, aliens))
+	}
+	requests = append(requests, generateIgnoredParameterTest(rng, aliens))
+	requests = append(requests, generateRepeatedParameterTest(rng))
+	requests = append(requests, generateMalformedValueTest(rng))
+
+	return requests
+}
+
+// Every filter, in a fixed order so that generation stays deterministic.
+func filterParameters() []string {
+	parameters := []string{QUERY_TYPE}
+	for _, field := range numericFields {
+		parameters = append(parameters, field+"_lte", field+"_gte")
+	}
+	return parameters
+}
+
+func randomFilterValue(rng *rand.Rand, parameter string) string {
+	if parameter == QUERY_TYPE {
+		return string(alienTypes[rng.Intn(len(alienTypes))])
+	}
+	return strconv.Itoa(rng.Intn(ALIEN_ATK_HP_SPD_UPPER))
+}
+
+func generateQueryTest(aliens []DetailedAlien, name string, points int, rawQuery string) NgrokRequest {
+	expectedAliens, err := EvaluateAlienQuery(aliens, rawQuery)
+	if err != nil {
+		// Generated queries are always valid, so this is a bug in the generator.
+		panic(fmt.Sprintf("generated an invalid query %q: %s", rawQuery, err))
+	}
+	return NgrokGetRequest{
+		Name:           name,
+		Points:         points,
+		Path:           NGROK_PATH + "?" + rawQuery,
+		ExpectedAliens: expectedAliens,
+	}
+}
+
+func generateCompoundFilterTest(rng *rand.Rand, aliens []DetailedAlien) NgrokRequest {
+	parameters := filterParameters()
+	count := utils.GenerateRandomNumWithinRange(rng, 2, 5)
+
+	queryParams := []string{}
+	for _, idx := range rng.Perm(len(parameters))[:count] {
+		queryParams = append(queryParams, parameters[idx]+"="+randomFilterValue(rng, parameters[idx]))
+	}
+	rawQuery := strings.Join(queryParams, "&")
+
+	return generateQueryTest(aliens, "Compound filter "+rawQuery, NGROK_COMPOUND_FILTER_POINTS, rawQuery)
+}
+
+func generateIgnoredParameterTest(rng *rand.Rand, aliens []DetailedAlien) NgrokRequest {
+	parameters := filterParameters()
+	parameter := parameters[rng.Intn(len(parameters))]
+	rawQuery := fmt.Sprintf("%s=%s&planet=%s", parameter, randomFilterValue(rng, parameter),
+		url.QueryEscape(data.AlienLastNames[rng.Intn(len(data.AlienLastNames))]))
+
+	return generateQueryTest(aliens, "Ignore unknown parameter "+rawQuery, NGROK_IGNORED_QUERY_POINTS, rawQuery)
+}
+
+func generateRepeatedParameterTest(rng *rand.Rand) NgrokRequest {
+	parameters := filterParameters()
+	parameter := parameters[rng.Intn(len(parameters))]
+	rawQuery := fmt.Sprintf("%s=%s&%s=%s", parameter, randomFilterValue(rng, parameter),
+		parameter, randomFilterValue(rng, parameter))
+
+	return NgrokMalformedQueryRequest{
+		Name:   "Reject repeated parameter " + rawQuery,
+		Points: NGROK_MALFORMED_QUERY_POINTS,
+		Path:   NGROK_PATH + "?" + rawQuery,
+	}
+}
+
+func generateMalformedValueTest(rng *rand.Rand) NgrokRequest {
+	field := numericFields[rng.Intn(len(numericFields))]
+	malformedQueries := []string{
+		field + "_gte=high",
+		field + "_lte=" + strconv.Itoa(rng.Intn(ALIEN_ATK_HP_SPD_UPPER)) + ".5",
+		QUERY_LIMIT + "=-" + strconv.Itoa(utils.GenerateRandomNumWithinRange(rng, 1, NGROK_PAGE_UPPER_BOUND)),
+		QUERY_SORT + "=first_name",
+		QUERY_TYPE + "=Civilian",
+	}
+	rawQuery := malformedQueries[rng.Intn(len(malformedQueries))]
+
+	return NgrokMalformedQueryRequest{
+		Name:   "Reject malformed query " + rawQuery,
+		Points: NGROK_MALFORMED_QUERY_POINTS,
+		Path:   NGROK_PATH + "?" + rawQuery,
+	}
+}
@@ -0,0 +1,101 @@
+package services_test
+
+import (
+	"generate_technical_challenge_2025/internal/services"
+	"net/url"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateAlienQueryAndsFilters(t *testing.T) {
+	aliens := services.GenerateNgrokAliens(NGROK_RNG, NGROK_UUID)
+
+	actual, err := services.EvaluateAlienQuery(aliens, "type=Elite&spd_gte=2&hp_lte=3")
+	require.NoError(t, err)
+	expected := lo.Filter(aliens, func(alien services.DetailedAlien, _ int) bool {
+		return alien.Type == services.AlienTypeElite && alien.Spd >= 2 && alien.BaseAlien.Hp <= 3
+	})
+	assert.Equal(t, expected, actual)
+
+	// Unknown parameters are ignored.
+	withUnknown, err := services.EvaluateAlienQuery(aliens, "type=Elite&spd_gte=2&hp_lte=3&planet=Mars")
+	require.NoError(t, err)
+	assert.Equal(t, actual, withUnknown)
+
+	// Contradicting filters match nothing, which is still an array.
+	none, err := services.EvaluateAlienQuery(aliens, "atk_lte=1&atk_gte=3")
+	require.NoError(t, err)
+	assert.NotNil(t, none)
+	assert.Empty(t, none)
+}
+
+func TestEvaluateAlienQuerySortsThenPaginates(t *testing.T) {
+	aliens := services.GenerateNgrokAliens(NGROK_RNG, NGROK_UUID)
+
+	sorted, err := services.EvaluateAlienQuery(aliens, "sort=spd&order=desc")
+	require.NoError(t, err)
+	assert.Len(t, sorted, len(aliens))
+	assert.ElementsMatch(t, aliens, sorted)
+	for idx := 1; idx < len(sorted); idx++ {
+		assert.GreaterOrEqual(t, sorted[idx-1].Spd, sorted[idx].Spd)
+	}
+
+	page, err := services.EvaluateAlienQuery(aliens, "sort=spd&order=desc&limit=7&offset=3")
+	require.NoError(t, err)
+	assert.Equal(t, sorted[3:10], page)
+
+	pastTheEnd, err := services.EvaluateAlienQuery(aliens, "offset=100000")
+	require.NoError(t, err)
+	assert.Empty(t, pastTheEnd)
+}
+
+func TestEvaluateAlienQueryRejectsMalformedQueries(t *testing.T) {
+	aliens := services.GenerateNgrokAliens(NGROK_RNG, NGROK_UUID)
+	for _, rawQuery := range []string{
+		"spd_gte=1&spd_gte=2",
+		"type=Elite&type=Boss",
+		"hp_lte=high",
+		"atk_gte=1.5",
+		"limit=-1",
+		"offset=abc",
+		"sort=first_name",
+		"sort=spd&order=sideways",
+		"type=Civilian",
+	} {
+		_, err := services.EvaluateAlienQuery(aliens, rawQuery)
+		assert.ErrorIs(t, err, services.ErrMalformedQuery, rawQuery)
+	}
+}
+
+// Every GET the generators expect an alien list from must agree with the oracle.
+func TestEvaluateAlienQueryAgreesWithGeneratedRequests(t *testing.T) {
+	for version := 1; version <= services.CURRENT_CHALLENGE_VERSION; version++ {
+		if _, ok := services.GetChallengeGenerators(version); !ok {
+			continue
+		}
+		challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, version)
+		posted := challenge.Requests[1].(services.NgrokPostRequest).Body
+		for _, request := range challenge.Requests {
+			switch req := request.(type) {
+			case services.NgrokGetRequest:
+				path, err := url.Parse(req.Path)
+				require.NoError(t, err)
+				expected, err := services.EvaluateAlienQuery(posted, path.RawQuery)
+				require.NoError(t, err, req.Name)
+				if len(req.ExpectedAliens) == 0 {
+					assert.Empty(t, expected, req.Name)
+				} else {
+					assert.Equal(t, req.ExpectedAliens, expected, req.Name)
+				}
+			case services.NgrokMalformedQueryRequest:
+				path, err := url.Parse(req.Path)
+				require.NoError(t, err)
+				_, err = services.EvaluateAlienQuery(posted, path.RawQuery)
+				assert.ErrorIs(t, err, services.ErrMalformedQuery, req.Name)
+			}
+		}
+	}
+}
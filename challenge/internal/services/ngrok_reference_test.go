@@ -0,0 +1,141 @@
+package services_test
+
+import (
+	"context"
+	"generate_technical_challenge_2025/internal/reference"
+	"generate_technical_challenge_2025/internal/services"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gradeReferenceServer(t *testing.T, faults reference.Faults, memberID uuid.UUID, version int) (services.NgrokChallenge, services.NgrokChallengeScore) {
+	server := reference.CreateServer(faults)
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(memberID, version)
+	score := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *serverURL, challenge)
+	if score.Valid {
+		require.Len(t, score.Results, len(challenge.Requests))
+	}
+	return challenge, score
+}
+
+// Checks that only the affected results lost points, and returns the total points lost. Affected results can
+// still earn every point, e.g. when no alien is on the boundary of an off by one filter.
+func assertOnlyDeducted(t *testing.T, score services.NgrokChallengeScore, affected func(result services.NgrokRequestResult) bool) int {
+	lost := 0
+	for _, result := range score.Results {
+		if !affected(result) {
+			assert.Equal(t, result.PointsPossible, result.PointsEarned, result.Name)
+		}
+		lost += result.PointsPossible - result.PointsEarned
+	}
+	assert.Equal(t, lost, score.Score)
+	return lost
+}
+
+// Returns every ID that more than one of the aliens has.
+func duplicatedAlienIDs(aliens []services.DetailedAlien) []string {
+	return lo.FindDuplicates(lo.Map(aliens, func(alien services.DetailedAlien, _ int) string { return alien.ID }))
+}
+
+func TestGradeNgrokServerGivesReferenceServerAPerfectScore(t *testing.T) {
+	for version := 1; version <= services.CURRENT_CHALLENGE_VERSION; version++ {
+		if _, ok := services.GetChallengeGenerators(version); !ok {
+			continue
+		}
+		challenge, score := gradeReferenceServer(t, reference.Faults{}, GOLDEN_MEMBER_ID, version)
+		assert.True(t, score.Valid, version)
+		if version == 1 {
+			// Version 1 posts aliens whose IDs can collide, and collapses them into one, see CalculateAlienDistance.
+			duplicated := duplicatedAlienIDs(challenge.Requests[1].(services.NgrokPostRequest).Body)
+			assertOnlyDeducted(t, score, func(result services.NgrokRequestResult) bool {
+				return len(lo.Intersect(result.MismatchedIDs, duplicated)) > 0
+			})
+			continue
+		}
+		assert.Zero(t, score.Score, version)
+		for _, result := range score.Results {
+			assert.NoError(t, result.Err, result.Name)
+			assert.Equal(t, result.PointsPossible, result.PointsEarned, result.Name)
+		}
+	}
+
+	_, score := gradeReferenceServer(t, reference.Faults{}, NGROK_UUID, services.CURRENT_CHALLENGE_VERSION)
+	assert.True(t, score.Valid)
+	assert.Zero(t, score.Score)
+}
+
+func TestGradeNgrokServerDeductsForOffByOneFilters(t *testing.T) {
+	_, score := gradeReferenceServer(t, reference.Faults{OffByOneFilters: true}, GOLDEN_MEMBER_ID, services.CURRENT_CHALLENGE_VERSION)
+	assert.True(t, score.Valid)
+	lost := assertOnlyDeducted(t, score, func(result services.NgrokRequestResult) bool {
+		// Malformed values are still rejected.
+		return result.Compared && (strings.Contains(result.Path, "_lte=") || strings.Contains(result.Path, "_gte="))
+	})
+	assert.Positive(t, lost)
+}
+
+func TestGradeNgrokServerDeductsForIgnoredTypeFilter(t *testing.T) {
+	_, score := gradeReferenceServer(t, reference.Faults{IgnoreTypeFilter: true}, GOLDEN_MEMBER_ID, services.CURRENT_CHALLENGE_VERSION)
+	assert.True(t, score.Valid)
+	lost := assertOnlyDeducted(t, score, func(result services.NgrokRequestResult) bool {
+		return strings.Contains(result.Path, "type=")
+	})
+	assert.Positive(t, lost)
+}
+
+func TestGradeNgrokServerDeductsForIgnoredFilters(t *testing.T) {
+	_, score := gradeReferenceServer(t, reference.Faults{IgnoreFilters: true}, GOLDEN_MEMBER_ID, services.CURRENT_CHALLENGE_VERSION)
+	assert.True(t, score.Valid)
+	lost := assertOnlyDeducted(t, score, func(result services.NgrokRequestResult) bool {
+		return strings.Contains(result.Path, "?")
+	})
+	assert.Positive(t, lost)
+}
+
+func TestGradeNgrokServerRejectsPostWithoutCreated(t *testing.T) {
+	for version := 1; version <= services.CURRENT_CHALLENGE_VERSION; version++ {
+		if _, ok := services.GetChallengeGenerators(version); !ok {
+			continue
+		}
+		_, score := gradeReferenceServer(t, reference.Faults{PostReturnsOK: true}, GOLDEN_MEMBER_ID, version)
+		assert.False(t, score.Valid)
+		assert.Contains(t, score.Reason, "expected status 201, got 200")
+	}
+}
+
+func TestGradeNgrokServerDeductsForExtraAliens(t *testing.T) {
+	extra := []services.DetailedAlien{
+		services.CreateDetailedAlien("extra-1", 1, 1, 1, "Zorp", "Glorp", services.AlienTypeBoss, ""),
+		services.CreateDetailedAlien("extra-2", 2, 2, 2, "Blip", "Blop", services.AlienTypeElite, ""),
+	}
+	challenge, score := gradeReferenceServer(t, reference.Faults{ExtraAliens: extra}, GOLDEN_MEMBER_ID, services.CURRENT_CHALLENGE_VERSION)
+	assert.True(t, score.Valid)
+
+	for idx, request := range challenge.Requests {
+		result := score.Results[idx]
+		switch req := request.(type) {
+		case services.NgrokGetRequest:
+			if req.SortField == "" {
+				// Each extra alien is 1 point of distance.
+				assert.Equal(t, len(extra), result.Distance, result.Name)
+				assert.Equal(t, max(result.PointsPossible-len(extra), 0), result.PointsEarned, result.Name)
+			}
+			assert.Less(t, result.PointsEarned, result.PointsPossible, result.Name)
+		case services.NgrokStressRequest:
+			assert.Less(t, result.PointsEarned, result.PointsPossible, result.Name)
+		default:
+			// Invalid POST checks only compare the aliens they posted.
+			assert.Equal(t, result.PointsPossible, result.PointsEarned, result.Name)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+const (
+	NGROK_STRESS_BATCHES            = 6
+	NGROK_STRESS_POST_POINTS        = 5
+	NGROK_STRESS_READ_POINTS        = 5
+	NGROK_STRESS_CONSISTENCY_POINTS = 10
+	NGROK_STRESS_DELETE_POINTS      = 5
+)
+
+// Clears the aliens, then sends every batch as its own POST /api/aliens at the same time, with a
+// GET /api/aliens interleaved between each of them. Earns points for:
+//   - Every POST returning a 201.
+//   - Every interleaved GET returning only posted aliens, each at most once.
+//   - A GET /api/aliens afterwards returning every posted alien exactly once.
+//   - Repeating DELETE /api/aliens succeeding, and leaving no aliens behind.
+type NgrokStressRequest struct {
+	Name    string
+	Path    string
+	Batches [][]DetailedAlien // Disjoint, so that no POST should be rejected.
+}
+
+func (t NgrokStressRequest) GetName() string {
+	return t.Name
+}
+
+func (t NgrokStressRequest) GetTotalPossiblePoints() int {
+	return NGROK_STRESS_POST_POINTS + NGROK_STRESS_READ_POINTS + NGROK_STRESS_CONSISTENCY_POINTS + NGROK_STRESS_DELETE_POINTS
+}
+
+// Only the first error is kept, but every part is still graded.
+func (t NgrokStressRequest) Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult {
+	result := newNgrokRequestResult(t, http.MethodPost, t.Path)
+	expectedAliens := slices.Concat(t.Batches...)
+
+	// The aliens have been updated by earlier requests, so start over.
+	if err := t.deleteAll(ctx, client, baseURL, &result); err != nil {
+		return result.fail(fmt.Errorf("failed to clear aliens: %w", err))
+	}
+
+	// Each goroutine only writes to its own slot, so no locking is needed.
+	postErrs := make([]error, len(t.Batches))
+	getErrs := make([]error, len(t.Batches))
+	wg := sync.WaitGroup{}
+	wg.Add(2 * len(t.Batches))
+	for idx, batch := range t.Batches {
+		go func() {
+			defer wg.Done()
+			postErrs[idx] = t.postBatch(ctx, client, baseURL, batch)
+		}()
+		go func() {
+			defer wg.Done()
+			getErrs[idx] = t.getDuringPosts(ctx, client, baseURL, expectedAliens)
+		}()
+	}
+	wg.Wait()
+
+	if err := firstError(postErrs); err != nil {
+		result.recordError(fmt.Errorf("concurrent POST failed: %w", err))
+	} else {
+		result.PointsEarned += NGROK_STRESS_POST_POINTS
+	}
+	if err := firstError(getErrs); err != nil {
+		result.recordError(fmt.Errorf("GET during concurrent POSTs failed: %w", err))
+	} else {
+		result.PointsEarned += NGROK_STRESS_READ_POINTS
+	}
+
+	// Every posted alien should be stored exactly once, in any order.
+	actualAliens, err := t.getAll(ctx, client, baseURL, &result)
+	if err != nil {
+		result.recordError(fmt.Errorf("GET after concurrent POSTs failed: %w", err))
+	} else {
+		result.compare(expectedAliens, actualAliens, matchAliensByID)
+		result.PointsEarned += max(NGROK_STRESS_CONSISTENCY_POINTS-result.Distance, 0)
+		if result.Distance != 0 {
+			result.recordError(fmt.Errorf("expected %d aliens after concurrent POSTs, got %d with %d differences",
+				len(expectedAliens), len(actualAliens), result.Distance))
+		}
+	}
+
+	// Deleting twice should succeed both times.
+	for range 2 {
+		if err := t.deleteAll(ctx, client, baseURL, &result); err != nil {
+			result.recordError(fmt.Errorf("repeated DELETE failed: %w", err))
+			return result
+		}
+	}
+	remainingAliens, err := t.getAll(ctx, client, baseURL, &result)
+	if err != nil {
+		result.recordError(fmt.Errorf("GET after repeated DELETE failed: %w", err))
+		return result
+	}
+	if len(remainingAliens) != 0 {
+		result.recordError(fmt.Errorf("expected no aliens after repeated DELETE, got %d", len(remainingAliens)))
+		return result
+	}
+	result.PointsEarned += NGROK_STRESS_DELETE_POINTS
+	return result
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t NgrokStressRequest) deleteAll(ctx context.Context, client *http.Client, baseURL string, result *NgrokRequestResult) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, baseURL+t.Path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := sendNgrokRequest(client, req, result)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("expected status 204 or 200, got %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (t NgrokStressRequest) postBatch(ctx context.Context, client *http.Client, baseURL string, batch []DetailedAlien) error {
+	bodyBytes, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+t.Path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Latency and status are only reported for the requests sent one at a time.
+	resp, err := sendNgrokRequest(client, req, &NgrokRequestResult{})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("expected status 201, got %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// The POSTs may or may not have been stored yet, but whatever is returned must have been posted, and only once.
+func (t NgrokStressRequest) getDuringPosts(ctx context.Context, client *http.Client, baseURL string, expectedAliens []DetailedAlien) error {
+	actualAliens, err := t.getAll(ctx, client, baseURL, &NgrokRequestResult{})
+	if err != nil {
+		return err
+	}
+
+	seenIDs := map[string]bool{}
+	for _, actual := range actualAliens {
+		if seenIDs[actual.ID] {
+			return fmt.Errorf("alien %s was returned more than once", actual.ID)
+		}
+		seenIDs[actual.ID] = true
+		if !slices.ContainsFunc(expectedAliens, func(expected DetailedAlien) bool { return aliensEqual(expected, actual) }) {
+			return fmt.Errorf("alien %s was never posted", actual.ID)
+		}
+	}
+	return nil
+}
+
+func (t NgrokStressRequest) getAll(ctx context.Context, client *http.Client, baseURL string, result *NgrokRequestResult) ([]DetailedAlien, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+t.Path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := sendNgrokRequest(client, req, result)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var aliens []DetailedAlien
+	if err := json.NewDecoder(resp.Body).Decode(&aliens); err != nil {
+		return nil, fmt.Errorf("invalid JSON response: %w", err)
+	}
+	return aliens, nil
+}
+
+// Splits the aliens into NGROK_STRESS_BATCHES disjoint batches, posted concurrently after every other request.
+func GenerateStressTest(aliens []DetailedAlien) NgrokRequest {
+	batches := [][]DetailedAlien{}
+	batchSize := (len(aliens) + NGROK_STRESS_BATCHES - 1) / NGROK_STRESS_BATCHES
+	for batch := range slices.Chunk(aliens, batchSize) {
+		batches = append(batches, slices.Clone(batch))
+	}
+
+	return NgrokStressRequest{
+		Name:    fmt.Sprintf("Concurrent POST of %d batches", len(batches)),
+		Path:    NGROK_PATH,
+		Batches: batches,
+	}
+}
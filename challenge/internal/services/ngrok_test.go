@@ -1,14 +1,26 @@
 package services_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"generate_technical_challenge_2025/internal/reference"
 	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/transactions"
 	"generate_technical_challenge_2025/internal/utils"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"slices"
+	"sync"
+	"time"
 
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -37,7 +49,8 @@ func TestGenerateRandomFilterTests(t *testing.T) {
 
 func TestCalculateAlienDistance(t *testing.T) {
 	firstRNG := utils.CreateRNGFromHash(NGROK_UUID)
-	aliens := services.GenerateNgrokAliens(firstRNG, NGROK_UUID)
+	// Aliens with colliding IDs collapse into one, see TestCalculateMatchedAlienDistanceWithDuplicateIDs.
+	aliens := services.GenerateUniqueNgrokAliens(firstRNG, NGROK_UUID)
 	// Aliens are 0 distance from themselves.
 	dist := services.CalculateAlienDistance(aliens, aliens)
 	assert.True(t, dist == 0)
@@ -84,3 +97,302 @@ func TestCalculateAlienDistance(t *testing.T) {
 	distWithAllTheSameAlien := services.CalculateAlienDistance(aliens, allTheSameAlien)
 	assert.True(t, distWithAllTheSameAlien == len(aliens)-1)
 }
+
+// Candidate server that stores whatever is posted, but ignores every filter.
+var UNFILTERED_FAULTS = reference.Faults{IgnoreFilters: true, AcceptInvalidAliens: true}
+
+func TestGradeNgrokServerCollectsEveryResult(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	// The unfiltered server fails the checks added after version 1.
+	challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, 1)
+	posted := challenge.Requests[1].(services.NgrokPostRequest).Body
+	totalPossiblePoints := lo.SumBy(challenge.Requests, func(request services.NgrokRequest) int {
+		return request.GetTotalPossiblePoints()
+	})
+
+	var firstScore *services.NgrokChallengeScore
+	// The GET requests are graded concurrently, so grade a few times to make sure no points go missing.
+	for range 3 {
+		score := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *serverURL, challenge)
+		assert.True(t, score.Valid)
+		assert.Len(t, score.Results, len(challenge.Requests))
+		earned := lo.SumBy(score.Results, func(result services.NgrokRequestResult) int {
+			return result.PointsEarned
+		})
+		assert.Equal(t, totalPossiblePoints-earned, score.Score)
+		for idx, result := range score.Results {
+			assert.Equal(t, challenge.Requests[idx].GetName(), result.Name)
+			assert.NoError(t, result.Err)
+			assert.NotZero(t, result.StatusCode)
+		}
+		// Every alien comes back on the unfiltered GET, version 1 only docks aliens with colliding IDs.
+		assert.Equal(t, services.CalculateAlienDistance(posted, posted), score.Results[2].Distance)
+		if score.Results[2].Distance == 0 {
+			assert.Equal(t, services.NGROK_GET_ALL_POINTS, score.Results[2].PointsEarned)
+		}
+
+		if firstScore == nil {
+			firstScore = &score
+		}
+		assert.Equal(t, firstScore.Score, score.Score)
+	}
+}
+
+func TestGradeNgrokServerReportsAlienDiff(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	// The unfiltered server fails the checks added after version 1.
+	challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, 1)
+	posted := challenge.Requests[1].(services.NgrokPostRequest).Body
+
+	score := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *serverURL, challenge)
+	assert.True(t, score.Valid)
+	assert.False(t, score.Results[0].Compared)
+	assert.False(t, score.Results[1].Compared)
+	assert.Equal(t, http.MethodPost, score.Results[1].Method)
+
+	for idx, result := range score.Results[2:] {
+		request := challenge.Requests[idx+2].(services.NgrokGetRequest)
+		expectedIDs := lo.Map(request.ExpectedAliens, func(alien services.DetailedAlien, _ int) string { return alien.ID })
+
+		assert.True(t, result.Compared)
+		assert.Equal(t, http.MethodGet, result.Method)
+		assert.Equal(t, request.Path, result.Path)
+		assert.Equal(t, len(request.ExpectedAliens), result.ExpectedCount)
+		// Filters are ignored, so every posted alien comes back and nothing is missing.
+		assert.Equal(t, len(posted), result.ActualCount)
+		assert.Empty(t, result.MissingIDs)
+		// Version 1 collapses aliens with the same ID into one, which only mismatches colliding IDs.
+		assert.Subset(t, duplicatedAlienIDs(posted), result.MismatchedIDs)
+		assert.Len(t, result.UnexpectedIDs, min(services.NGROK_DIFF_SAMPLE_SIZE, len(posted)-len(request.ExpectedAliens)))
+		for _, id := range result.UnexpectedIDs {
+			assert.False(t, slices.Contains(expectedIDs, id))
+		}
+	}
+}
+
+func TestGradeNgrokServerExtendedChecks(t *testing.T) {
+	server := reference.CreateServer(reference.Faults{})
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	for version := 4; version <= services.CURRENT_CHALLENGE_VERSION; version++ {
+		challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, version)
+		// Aliens are graded by ID, which only works if no two posted aliens share one.
+		posted := challenge.Requests[1].(services.NgrokPostRequest).Body
+		assert.Len(t, lo.UniqBy(posted, func(alien services.DetailedAlien) string { return alien.ID }), len(posted))
+
+		requestTypes := lo.Map(challenge.Requests, func(request services.NgrokRequest, _ int) string {
+			return fmt.Sprintf("%T", request)
+		})
+		assert.Contains(t, requestTypes, "services.NgrokGetAlienRequest")
+		assert.Contains(t, requestTypes, "services.NgrokPatchRequest")
+		assert.Contains(t, requestTypes, "services.NgrokDeleteAlienRequest")
+		assert.Contains(t, requestTypes, "services.NgrokMalformedQueryRequest")
+		assert.Contains(t, requestTypes, "services.NgrokInvalidPostRequest")
+		if version >= 5 {
+			assert.Equal(t, "services.NgrokStressRequest", requestTypes[len(requestTypes)-1])
+		}
+
+		score := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *serverURL, challenge)
+		assert.True(t, score.Valid)
+		assert.Zero(t, score.Score)
+		for idx, result := range score.Results {
+			assert.Equal(t, challenge.Requests[idx].GetName(), result.Name)
+			assert.NoError(t, result.Err, result.Name)
+			assert.Equal(t, result.PointsPossible, result.PointsEarned, result.Name)
+		}
+	}
+}
+
+func TestGenerateUniqueNgrokChallengeWithoutStressRound(t *testing.T) {
+	service := services.CreateChallengeService(LOGGER, transactions.CreateChallengeTransactions(LOGGER, nil), services.NgrokLatencyConfig{}, false)
+	withStress := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION)
+	withoutStress := service.GenerateUniqueNgrokChallenge(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION)
+
+	// Only the stress round is left out.
+	assert.IsType(t, services.NgrokStressRequest{}, withStress.Requests[len(withStress.Requests)-1])
+	assert.Equal(t, withStress.Requests[:len(withStress.Requests)-1], withoutStress.Requests)
+}
+
+func TestCalculateSortDistance(t *testing.T) {
+	aliens := services.GenerateUniqueNgrokAliens(NGROK_RNG, NGROK_UUID)[:20]
+	sorted := slices.Clone(aliens)
+	slices.SortStableFunc(sorted, func(a, b services.DetailedAlien) int { return a.Spd - b.Spd })
+	assert.Zero(t, services.CalculateSortDistance(sorted, sorted, services.SPD))
+
+	// Aliens tied on the sort field may come back in any order.
+	tied := slices.Clone(sorted)
+	for idx := 1; idx < len(tied); idx++ {
+		if tied[idx].Spd == tied[idx-1].Spd {
+			tied[idx], tied[idx-1] = tied[idx-1], tied[idx]
+		}
+	}
+	assert.Zero(t, services.CalculateSortDistance(sorted, tied, services.SPD))
+
+	reversed := slices.Clone(sorted)
+	slices.Reverse(reversed)
+	assert.Positive(t, services.CalculateSortDistance(sorted, reversed, services.SPD))
+	// The same aliens in the wrong order still match as a set.
+	assert.Zero(t, services.CalculateAlienDistance(sorted, reversed))
+}
+
+// Generated alien IDs can collide, and returning exactly what was posted must still be a perfect match.
+func TestCalculateMatchedAlienDistanceWithDuplicateIDs(t *testing.T) {
+	aliens := services.GenerateNgrokAliens(NGROK_RNG, NGROK_UUID)[:3]
+	duplicate := aliens[0]
+	duplicate.FirstName += "Duplicate"
+	withDuplicate := append(slices.Clone(aliens), duplicate)
+
+	assert.Zero(t, services.CalculateMatchedAlienDistance(withDuplicate, withDuplicate))
+	reversed := slices.Clone(withDuplicate)
+	slices.Reverse(reversed)
+	assert.Zero(t, services.CalculateMatchedAlienDistance(withDuplicate, reversed))
+
+	// Only returning one of the two aliens with the same ID is one missing alien.
+	assert.Equal(t, 1, services.CalculateMatchedAlienDistance(withDuplicate, aliens))
+	// Returning the same alien twice is one wrong alien.
+	assert.Equal(t, 1, services.CalculateMatchedAlienDistance(withDuplicate, append(slices.Clone(aliens), aliens[0])))
+
+	// Versions before 4 collapse aliens with the same ID into the last one returned.
+	assert.Equal(t, 1, services.CalculateAlienDistance(withDuplicate, withDuplicate))
+}
+
+// Reads are graded concurrently while the posted aliens are still there, so none of them may change an alien.
+func TestGenerateExtendedTestsSeparatesUpdates(t *testing.T) {
+	reads, updates := services.GenerateExtendedTests(NGROK_RNG, services.GenerateUniqueNgrokAliens(NGROK_RNG, NGROK_UUID))
+	for _, request := range reads {
+		assert.Contains(t, []string{"services.NgrokGetRequest", "services.NgrokGetAlienRequest"}, fmt.Sprintf("%T", request))
+	}
+	assert.Equal(t, []string{"services.NgrokPatchRequest", "services.NgrokDeleteAlienRequest"},
+		lo.Map(updates, func(request services.NgrokRequest, _ int) string { return fmt.Sprintf("%T", request) }))
+}
+
+func TestGenerateUniqueNgrokAliens(t *testing.T) {
+	aliens := services.GenerateUniqueNgrokAliens(NGROK_RNG, NGROK_UUID)
+	assert.GreaterOrEqual(t, len(aliens), services.NUM_NGROK_ALIENS_LOWER_BOUND)
+	assert.Less(t, len(aliens), services.NUM_NGROK_ALIENS_UPPER_BOUND)
+	ids := lo.Map(aliens, func(alien services.DetailedAlien, _ int) string { return alien.ID })
+	assert.Equal(t, len(ids), len(lo.Uniq(ids)))
+}
+
+// A server that accepts anything should not get the validation points.
+func TestGradeNgrokServerPenalizesAcceptingInvalidAliens(t *testing.T) {
+	server := reference.CreateServer(UNFILTERED_FAULTS)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	challenge := CHALLENGE_SERVICE_IMPL.GenerateUniqueNgrokChallenge(NGROK_UUID, 4)
+	score := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *serverURL, challenge)
+	assert.True(t, score.Valid)
+
+	invalidPosts := 0
+	for idx, request := range challenge.Requests {
+		if _, ok := request.(services.NgrokInvalidPostRequest); !ok {
+			continue
+		}
+		invalidPosts++
+		result := score.Results[idx]
+		assert.Equal(t, request.GetName(), result.Name)
+		assert.True(t, result.Compared)
+		if _, ok := request.(services.NgrokInvalidPostRequest).Body.(services.DetailedAlien); ok {
+			// The server cannot decode a single alien, so it stores nothing, and the aliens that earlier checks
+			// wrongly stored are not counted again.
+			assert.Equal(t, result.PointsPossible, result.PointsEarned, result.Name)
+		} else {
+			assert.Less(t, result.PointsEarned, result.PointsPossible, result.Name)
+		}
+	}
+	assert.Equal(t, 5, invalidPosts)
+}
+
+func TestGenerateStressTest(t *testing.T) {
+	aliens := services.GenerateUniqueNgrokAliens(NGROK_RNG, NGROK_UUID)
+	request := services.GenerateStressTest(aliens).(services.NgrokStressRequest)
+	assert.Len(t, request.Batches, services.NGROK_STRESS_BATCHES)
+	assert.Equal(t, aliens, slices.Concat(request.Batches...))
+}
+
+// Candidate server that loses concurrent writes, and fails to DELETE twice in a row.
+func createRacyAlienServer() *httptest.Server {
+	var mu sync.Mutex
+	stored := []services.DetailedAlien{}
+	deleted := false
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			mu.Lock()
+			defer mu.Unlock()
+			if deleted {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			stored = []services.DetailedAlien{}
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPost:
+			var aliens []services.DetailedAlien
+			if err := json.NewDecoder(r.Body).Decode(&aliens); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			// Read, then write back later, overwriting whatever was posted in between.
+			mu.Lock()
+			snapshot := slices.Clone(stored)
+			mu.Unlock()
+			time.Sleep(50 * time.Millisecond)
+			mu.Lock()
+			stored = append(snapshot, aliens...)
+			deleted = false
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			mu.Lock()
+			defer mu.Unlock()
+			json.NewEncoder(w).Encode(stored)
+		}
+	}))
+}
+
+func TestGradeNgrokServerStressRound(t *testing.T) {
+	aliens := services.GenerateUniqueNgrokAliens(NGROK_RNG, NGROK_UUID)
+	challenge := services.NgrokChallenge{Requests: []services.NgrokRequest{services.GenerateStressTest(aliens)}}
+
+	compliant := reference.CreateServer(reference.Faults{})
+	defer compliant.Close()
+	compliantURL, err := url.Parse(compliant.URL)
+	assert.NoError(t, err)
+
+	score := CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *compliantURL, challenge)
+	assert.True(t, score.Valid)
+	assert.Zero(t, score.Score)
+	require.Len(t, score.Results, 1)
+	assert.NoError(t, score.Results[0].Err)
+
+	racy := createRacyAlienServer()
+	defer racy.Close()
+	racyURL, err := url.Parse(racy.URL)
+	assert.NoError(t, err)
+
+	score = CHALLENGE_SERVICE_IMPL.GradeNgrokServer(context.Background(), *racyURL, challenge)
+	assert.True(t, score.Valid)
+	require.Len(t, score.Results, 1)
+	result := score.Results[0]
+	assert.Error(t, result.Err)
+	assert.True(t, result.Compared)
+	assert.Less(t, result.ActualCount, len(aliens))
+	assert.NotEmpty(t, result.MissingIDs)
+	// Lost writes cost the consistency points, and the repeated DELETE fails.
+	assert.LessOrEqual(t, result.PointsEarned,
+		services.NGROK_STRESS_POST_POINTS+services.NGROK_STRESS_READ_POINTS+services.NGROK_STRESS_CONSISTENCY_POINTS-1)
+	assert.Greater(t, result.PointsPossible-result.PointsEarned, services.NGROK_STRESS_DELETE_POINTS)
+}
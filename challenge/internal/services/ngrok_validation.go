@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"generate_technical_challenge_2025/internal/utils"
+	"math/rand"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const NGROK_INVALID_POST_POINTS = 10
+
+// POST /api/aliens with an invalid body, expects a 4xx. Then GET /api/aliens to make sure none of it was stored.
+type NgrokInvalidPostRequest struct {
+	Name   string
+	Points int
+	Path   string
+	Body   any // Sent as is, so it does not have to be an array of aliens.
+	// Expects the aliens with the IDs in the body to be the ones that were posted before. Other aliens are left
+	// to the check that posted them, so a wrongly stored alien is only counted once.
+	Verify NgrokGetRequest
+}
+
+func (t NgrokInvalidPostRequest) GetName() string {
+	return t.Name
+}
+
+// Half of the points are for rejecting the body, the rest are earned by the follow up GET.
+func (t NgrokInvalidPostRequest) GetTotalPossiblePoints() int {
+	return t.Points + t.Verify.Points
+}
+
+func (t NgrokInvalidPostRequest) Execute(ctx context.Context, client *http.Client, baseURL string) NgrokRequestResult {
+	result := newNgrokRequestResult(t, http.MethodPost, t.Path)
+
+	bodyBytes, err := json.Marshal(t.Body)
+	if err != nil {
+		return result.fail(fmt.Errorf("failed to marshal request body: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+t.Path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return result.fail(fmt.Errorf("failed to create request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := sendNgrokRequest(client, req, &result)
+	if err != nil {
+		return result.fail(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < http.StatusBadRequest || resp.StatusCode >= http.StatusInternalServerError {
+		result.Err = fmt.Errorf("expected a 4xx status, got %d", resp.StatusCode)
+	} else {
+		result.PointsEarned = t.Points
+	}
+
+	// Whatever the status was, the stored aliens should not have changed.
+	verifyResult := t.Verify.Execute(ctx, client, baseURL)
+	result.PointsEarned += verifyResult.PointsEarned
+	result.Compared = verifyResult.Compared
+	result.ExpectedCount = verifyResult.ExpectedCount
+	result.ActualCount = verifyResult.ActualCount
+	result.Distance = verifyResult.Distance
+	result.MissingIDs = verifyResult.MissingIDs
+	result.UnexpectedIDs = verifyResult.UnexpectedIDs
+	result.MismatchedIDs = verifyResult.MismatchedIDs
+	if result.Err == nil && verifyResult.Err != nil {
+		result.Err = fmt.Errorf("follow up GET failed: %w", verifyResult.Err)
+	}
+	return result
+}
+
+// Generates:
+// - GET with a non-numeric filter value, which should be a 400.
+// - POST requests that should all be rejected without changing the posted aliens:
+//   - An alien without an id.
+//   - An alien with a negative spd.
+//   - An alien with an unknown type.
+//   - A single alien instead of an array.
+//   - An alien with the same id as one that was already posted.
+func GenerateValidationTests(rng *rand.Rand, memberID uuid.UUID, aliens []DetailedAlien) []NgrokRequest {
+	requests := []NgrokRequest{}
+
+	// Test 1: Non-numeric filter.
+	field := numericFields[rng.Intn(len(numericFields))]
+	rawQuery := field + "_gte=abc"
+	requests = append(requests, NgrokMalformedQueryRequest{
+		Name:   "Reject non-numeric filter " + rawQuery,
+		Points: NGROK_MALFORMED_QUERY_POINTS,
+		Path:   NGROK_PATH + "?" + rawQuery,
+	})
+
+	// Test 2: Missing id.
+	missingID := alienToMap(GenerateDetailedAlien(rng, memberID, len(aliens)))
+	delete(missingID, "id")
+	requests = append(requests, generateInvalidPostTest("POST alien without id", []any{missingID}, "", aliens))
+
+	// Test 3: Negative spd.
+	negativeSpd := GenerateDetailedAlien(rng, memberID, len(aliens)+1)
+	negativeSpd.Spd = -utils.GenerateRandomNumWithinRange(rng, ALIEN_ATK_HP_SPD_LOWER, ALIEN_ATK_HP_SPD_UPPER)
+	requests = append(requests, generateInvalidPostTest(
+		fmt.Sprintf("POST alien with spd=%d", negativeSpd.Spd), []DetailedAlien{negativeSpd}, negativeSpd.ID, aliens))
+
+	// Test 4: Unknown type.
+	unknownType := GenerateDetailedAlien(rng, memberID, len(aliens)+2)
+	unknownType.Type = AlienType([]string{"Civilian", "Overlord", "Drone"}[rng.Intn(3)])
+	requests = append(requests, generateInvalidPostTest(
+		fmt.Sprintf("POST alien with type=%s", unknownType.Type), []DetailedAlien{unknownType}, unknownType.ID, aliens))
+
+	// Test 5: Not an array.
+	single := GenerateDetailedAlien(rng, memberID, len(aliens)+3)
+	requests = append(requests, generateInvalidPostTest("POST a single alien instead of an array", single, single.ID, aliens))
+
+	// Test 6: Duplicate id, with otherwise new values.
+	duplicate := GenerateDetailedAlien(rng, memberID, len(aliens)+4)
+	duplicate.ID = aliens[rng.Intn(len(aliens))].ID
+	requests = append(requests, generateInvalidPostTest(
+		fmt.Sprintf("POST alien with duplicate id %s", duplicate.ID), []DetailedAlien{duplicate}, duplicate.ID, aliens))
+
+	return requests
+}
+
+// Only the alien with the given ID is verified, an alien posted without one comes back with an empty ID.
+func generateInvalidPostTest(name string, body any, id string, aliens []DetailedAlien) NgrokRequest {
+	return NgrokInvalidPostRequest{
+		Name:   name,
+		Points: NGROK_INVALID_POST_POINTS / 2,
+		Path:   NGROK_PATH,
+		Body:   body,
+		Verify: NgrokGetRequest{
+			Name:           "GET all aliens after " + name,
+			Points:         NGROK_INVALID_POST_POINTS - NGROK_INVALID_POST_POINTS/2,
+			Path:           NGROK_PATH,
+			ExpectedAliens: aliens,
+			OnlyIDs:        []string{id},
+		},
+	}
+}
+
+// Returns the alien as its JSON object, so that fields can be removed.
+func alienToMap(alien DetailedAlien) map[string]any {
+	bytes, _ := json.Marshal(alien)
+	fields := map[string]any{}
+	_ = json.Unmarshal(bytes, &fields)
+	return fields
+}
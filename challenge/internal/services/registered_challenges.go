@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"generate_technical_challenge_2025/internal/database/models"
+	"math"
+	"net/url"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// The alien invasion challenge, where members submit their commands for every wave.
+type algorithmChallenge struct {
+	service ChallengeService
+}
+
+// A single wave of the alien invasion challenge, as members are given it.
+type AlienWaveView struct {
+	ChallengeID uuid.UUID
+	Hp          int
+	Aliens      []Alien
+}
+
+func CreateAlgorithmChallenge(service ChallengeService) Challenge {
+	return algorithmChallenge{service: service}
+}
+
+func (a algorithmChallenge) Type() string {
+	return models.ALGORITHM_CHALLENGE_TYPE
+}
+
+func (a algorithmChallenge) Title() string {
+	return "Alien Invasion Challenge"
+}
+
+// Returns every wave keyed by challenge ID, see GenerateUniqueAlienChallenge.
+func (a algorithmChallenge) Generate(memberID uuid.UUID, version int) any {
+	return a.service.GenerateUniqueAlienChallenge(memberID, version)
+}
+
+// Returns every wave as an AlienWaveView, sorted by challenge ID so that members always get the same order.
+func (a algorithmChallenge) PublicView(memberID uuid.UUID, version int) any {
+	waves := a.service.GenerateUniqueAlienChallenge(memberID, version)
+	keys := lo.Keys(waves)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	return lo.Map(keys, func(key uuid.UUID, _ int) AlienWaveView {
+		return AlienWaveView{ChallengeID: key, Hp: waves[key].GetHpLeft(), Aliens: waves[key].SurveyRemainingAlienInvasion()}
+	})
+}
+
+// Expects a map[uuid.UUID]UserChallengeSubmission, which is saved with its score (see SaveAlienSubmission), and sets
+// an OracleAnswer as the details.
+func (a algorithmChallenge) Grade(ctx context.Context, memberID uuid.UUID, version int, submission any) (ChallengeGrade, error) {
+	waves, ok := submission.(map[uuid.UUID]UserChallengeSubmission)
+	if !ok {
+		return ChallengeGrade{}, ErrUnexpectedSubmission
+	}
+	answer := a.service.ScoreMemberSubmission(memberID, version, waves)
+	if err := a.service.SaveAlienSubmission(memberID, waves, answer); err != nil {
+		return ChallengeGrade{}, err
+	}
+	return ChallengeGrade{Valid: answer.Valid, Score: answer.Score, Message: answer.Message, Details: answer}, nil
+}
+
+// The score of submitting no commands for every wave.
+func (a algorithmChallenge) MaxScore(memberID uuid.UUID, version int) int {
+	score := 0
+	for _, state := range a.service.GenerateUniqueAlienChallenge(memberID, version) {
+		oracle := MemoizedOracleSolution(state)
+		score += int(math.Abs(float64(oracle.GetHpLeft()-state.GetHpLeft()))) +
+			int(math.Abs(float64(oracle.GetAliensLeft()-state.GetAliensLeft()))) +
+			oracle.GetNumberOfCommandsUsed()
+	}
+	return score
+}
+
+// The ngrok challenge, where members submit the URL of the server they built.
+type ngrokServerChallenge struct {
+	service ChallengeService
+}
+
+// A single check of the ngrok challenge, as members are told about it.
+type NgrokCheckView struct {
+	Name   string
+	Points int
+}
+
+func CreateNgrokServerChallenge(service ChallengeService) Challenge {
+	return ngrokServerChallenge{service: service}
+}
+
+func (n ngrokServerChallenge) Type() string {
+	return models.NGROK_CHALLENGE_TYPE
+}
+
+func (n ngrokServerChallenge) Title() string {
+	return "ngrok Challenge"
+}
+
+// Returns the NgrokChallenge, see GenerateUniqueNgrokChallenge.
+func (n ngrokServerChallenge) Generate(memberID uuid.UUID, version int) any {
+	return n.service.GenerateUniqueNgrokChallenge(memberID, version)
+}
+
+// Returns every check as an NgrokCheckView, members only see what was sent once their server is graded.
+func (n ngrokServerChallenge) PublicView(memberID uuid.UUID, version int) any {
+	return lo.Map(n.service.GenerateUniqueNgrokChallenge(memberID, version).Requests, func(request NgrokRequest, _ int) NgrokCheckView {
+		return NgrokCheckView{Name: request.GetName(), Points: request.GetTotalPossiblePoints()}
+	})
+}
+
+// Expects the url.URL of the member's server, which is graded in the background (see SubmitNgrokJob). The grade is
+// always pending, with the queued *models.NgrokJob as the details.
+func (n ngrokServerChallenge) Grade(ctx context.Context, memberID uuid.UUID, version int, submission any) (ChallengeGrade, error) {
+	serverURL, ok := submission.(url.URL)
+	if !ok {
+		return ChallengeGrade{}, ErrUnexpectedSubmission
+	}
+	job, err := n.service.SubmitNgrokJob(memberID, version, serverURL)
+	if err != nil {
+		return ChallengeGrade{}, err
+	}
+	return ChallengeGrade{Pending: true, Message: job.Message, Details: job}, nil
+}
+
+// Every point of every check.
+func (n ngrokServerChallenge) MaxScore(memberID uuid.UUID, version int) int {
+	return lo.SumBy(n.service.GenerateUniqueNgrokChallenge(memberID, version).Requests, func(request NgrokRequest) int {
+		return request.GetTotalPossiblePoints()
+	})
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"generate_technical_challenge_2025/internal/database/models"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// A take home challenge that members are scored on. Scores are how far a submission is from a perfect one,
+// so 0 is the best score of every challenge.
+//
+// To add a challenge, implement this and register it in CreateChallengeRegistry.
+type Challenge interface {
+	// Unique name of the challenge, stored as models.Score.ChallengeType.
+	Type() string
+	// Name of the challenge shown to members.
+	Title() string
+	// Generates the member's challenge for the version they registered with, including anything needed to grade it.
+	Generate(memberID uuid.UUID, version int) any
+	// The part of Generate that members are given, without any solutions.
+	PublicView(memberID uuid.UUID, version int) any
+	// Grades a submission and records its score, the submission must be the type the challenge expects (see each
+	// implementation).
+	Grade(ctx context.Context, memberID uuid.UUID, version int, submission any) (ChallengeGrade, error)
+	// Score of a valid submission that makes no progress at all.
+	MaxScore(memberID uuid.UUID, version int) int
+}
+
+// Outcome of grading a submission to any challenge.
+type ChallengeGrade struct {
+	// The submission is still being graded in the background, so only Details is set.
+	Pending bool
+	Valid   bool
+	Score   int // Only meaningful when Valid = true.
+	Message string
+	Details any // Challenge specific breakdown, e.g. OracleAnswer or *models.NgrokJob.
+}
+
+var (
+	ErrUnknownChallengeType   = errors.New("unknown challenge type")
+	ErrDuplicateChallengeType = errors.New("challenge type is already registered")
+	ErrUnexpectedSubmission   = errors.New("unexpected submission type for challenge")
+)
+
+// Every challenge members can be scored on, in the order they were registered.
+type ChallengeRegistry struct {
+	challenges []Challenge
+	byType     map[string]Challenge
+}
+
+// Creates a registry with every challenge members are scored on, in the order of the specification.
+func CreateChallengeRegistry(challengeService ChallengeService) *ChallengeRegistry {
+	registry := &ChallengeRegistry{byType: map[string]Challenge{}}
+	for _, challenge := range []Challenge{
+		CreateNgrokServerChallenge(challengeService),
+		CreateAlgorithmChallenge(challengeService),
+	} {
+		if err := registry.Register(challenge); err != nil {
+			// The challenges above are fixed, so this is a programming error.
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// Adds the challenge to the registry, failing if its type is already registered.
+func (r *ChallengeRegistry) Register(challenge Challenge) error {
+	if _, exists := r.byType[challenge.Type()]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateChallengeType, challenge.Type())
+	}
+	r.challenges = append(r.challenges, challenge)
+	r.byType[challenge.Type()] = challenge
+	return nil
+}
+
+// Returns the challenge with the given type, and whether it is registered.
+func (r *ChallengeRegistry) Get(challengeType string) (Challenge, bool) {
+	challenge, ok := r.byType[challengeType]
+	return challenge, ok
+}
+
+// Returns every registered challenge, in the order they were registered.
+func (r *ChallengeRegistry) All() []Challenge {
+	return append([]Challenge{}, r.challenges...)
+}
+
+// Returns every registered challenge that is part of the cohort, in the order they were registered.
+func (r *ChallengeRegistry) ForCohort(cohort models.Cohort) []Challenge {
+	return lo.Filter(r.challenges, func(challenge Challenge, _ int) bool {
+		return cohort.HasChallenge(challenge.Type())
+	})
+}
+
+// Returns the type of every registered challenge, in the order they were registered.
+func (r *ChallengeRegistry) Types() []string {
+	types := make([]string, len(r.challenges))
+	for idx, challenge := range r.challenges {
+		types[idx] = challenge.Type()
+	}
+	return types
+}
@@ -0,0 +1,115 @@
+package services_test
+
+import (
+	"context"
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A challenge that only has a type, for registering extra challenges.
+type stubChallenge struct {
+	services.Challenge
+	challengeType string
+}
+
+func (s stubChallenge) Type() string {
+	return s.challengeType
+}
+
+func TestChallengeRegistryOrderAndLookup(t *testing.T) {
+	registry := services.CreateChallengeRegistry(CHALLENGE_SERVICE_IMPL)
+	assert.Equal(t, []string{models.NGROK_CHALLENGE_TYPE, models.ALGORITHM_CHALLENGE_TYPE}, registry.Types())
+
+	challenge, ok := registry.Get(models.ALGORITHM_CHALLENGE_TYPE)
+	require.True(t, ok)
+	assert.Equal(t, models.ALGORITHM_CHALLENGE_TYPE, challenge.Type())
+	_, ok = registry.Get("unknown")
+	assert.False(t, ok)
+
+	// Modifying what All returns leaves the registry untouched.
+	all := registry.All()
+	all[0] = nil
+	assert.NotNil(t, registry.All()[0])
+}
+
+func TestChallengeRegistryRegister(t *testing.T) {
+	registry := services.CreateChallengeRegistry(CHALLENGE_SERVICE_IMPL)
+	require.NoError(t, registry.Register(stubChallenge{challengeType: "design"}))
+	assert.Equal(t, []string{models.NGROK_CHALLENGE_TYPE, models.ALGORITHM_CHALLENGE_TYPE, "design"}, registry.Types())
+
+	err := registry.Register(stubChallenge{challengeType: models.NGROK_CHALLENGE_TYPE})
+	assert.ErrorIs(t, err, services.ErrDuplicateChallengeType)
+	assert.Len(t, registry.All(), 3)
+}
+
+func TestChallengeRegistryForCohort(t *testing.T) {
+	cohort := models.CreateCohort("Fall", time.Now(), time.Time{}, []string{models.ALGORITHM_CHALLENGE_TYPE}, 1)
+	challenges := CHALLENGES.ForCohort(*cohort)
+	require.Len(t, challenges, 1)
+	assert.Equal(t, models.ALGORITHM_CHALLENGE_TYPE, challenges[0].Type())
+}
+
+func TestAlgorithmChallengeGrade(t *testing.T) {
+	recorder := &submissionRecorder{}
+	challenge := services.CreateAlgorithmChallenge(services.CreateChallengeService(LOGGER, recorder, services.NgrokLatencyConfig{}, true))
+	memberID := uuid.New()
+	waves := challenge.Generate(memberID, services.CURRENT_CHALLENGE_VERSION).(map[uuid.UUID]services.InvasionState)
+	assert.Len(t, challenge.PublicView(memberID, services.CURRENT_CHALLENGE_VERSION), services.NUM_WAVES)
+
+	perfect := lo.MapValues(waves, func(state services.InvasionState, _ uuid.UUID) services.UserChallengeSubmission {
+		oracleSol := services.MemoizedOracleSolution(state)
+		return services.UserChallengeSubmission{Hp: oracleSol.GetHpLeft(), Commands: oracleSol.GetCommandsUsed(), AliensLeft: oracleSol.GetAliensLeft()}
+	})
+	grade, err := challenge.Grade(context.Background(), memberID, services.CURRENT_CHALLENGE_VERSION, perfect)
+	require.NoError(t, err)
+	assert.True(t, grade.Valid)
+	assert.Zero(t, grade.Score)
+	assert.IsType(t, services.OracleAnswer{}, grade.Details)
+	// Grading records the submission.
+	require.NotNil(t, recorder.submission)
+	assert.Len(t, recorder.submission.Waves, services.NUM_WAVES)
+
+	// Submitting no commands scores the maximum.
+	idle := lo.MapValues(waves, func(state services.InvasionState, _ uuid.UUID) services.UserChallengeSubmission {
+		return services.UserChallengeSubmission{Hp: state.GetHpLeft(), Commands: []string{}, AliensLeft: state.GetAliensLeft()}
+	})
+	grade, err = challenge.Grade(context.Background(), memberID, services.CURRENT_CHALLENGE_VERSION, idle)
+	require.NoError(t, err)
+	assert.True(t, grade.Valid)
+	assert.Equal(t, challenge.MaxScore(memberID, services.CURRENT_CHALLENGE_VERSION), grade.Score)
+
+	_, err = challenge.Grade(context.Background(), memberID, services.CURRENT_CHALLENGE_VERSION, "commands")
+	assert.ErrorIs(t, err, services.ErrUnexpectedSubmission)
+}
+
+func TestNgrokServerChallengeGrade(t *testing.T) {
+	challenge := services.CreateNgrokServerChallenge(
+		services.CreateChallengeService(LOGGER, createFakeNgrokJobTransactions(), services.NgrokLatencyConfig{}, true))
+	generated := challenge.Generate(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION).(services.NgrokChallenge)
+	views := challenge.PublicView(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION).([]services.NgrokCheckView)
+	require.Len(t, views, len(generated.Requests))
+	assert.Equal(t, challenge.MaxScore(NGROK_UUID, services.CURRENT_CHALLENGE_VERSION), lo.SumBy(views, func(view services.NgrokCheckView) int {
+		return view.Points
+	}))
+
+	// The server is graded in the background, so the grade only has the queued job.
+	serverURL, err := url.Parse("http://localhost:8080")
+	require.NoError(t, err)
+	grade, err := challenge.Grade(context.Background(), NGROK_UUID, services.CURRENT_CHALLENGE_VERSION, *serverURL)
+	require.NoError(t, err)
+	assert.True(t, grade.Pending)
+	job := grade.Details.(*models.NgrokJob)
+	assert.Equal(t, models.NGROK_JOB_PENDING, job.Status)
+	assert.Equal(t, services.CURRENT_CHALLENGE_VERSION, job.ChallengeVersion)
+
+	_, err = challenge.Grade(context.Background(), NGROK_UUID, services.CURRENT_CHALLENGE_VERSION, serverURL.String())
+	assert.ErrorIs(t, err, services.ErrUnexpectedSubmission)
+}
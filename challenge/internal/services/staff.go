@@ -0,0 +1,59 @@
+package services
+
+import (
+	"crypto/sha256"
+	"errors"
+	"slices"
+)
+
+// Roles of the staff using the admin API. Admins can do everything interviewers can.
+const (
+	ROLE_INTERVIEWER = "interviewer"
+	ROLE_ADMIN       = "admin"
+)
+
+var (
+	ErrInvalidStaffToken = errors.New("invalid staff token")
+	ErrRoleNotAllowed    = errors.New("role is not allowed to perform this operation")
+)
+
+var roleGrants = map[string][]string{
+	ROLE_INTERVIEWER: {ROLE_INTERVIEWER},
+	ROLE_ADMIN:       {ROLE_INTERVIEWER, ROLE_ADMIN},
+}
+
+// Looks up the role of the tokens given to interviewers and admins.
+type StaffAuthenticator struct {
+	roles map[[sha256.Size]byte]string // Keyed by the hash of the token, so lookups take the same time for every token.
+}
+
+// Empty tokens are ignored, a token given to both roles is an admin token.
+func CreateStaffAuthenticator(interviewerTokens []string, adminTokens []string) StaffAuthenticator {
+	roles := map[[sha256.Size]byte]string{}
+	add := func(tokens []string, role string) {
+		for _, token := range tokens {
+			if token != "" {
+				roles[sha256.Sum256([]byte(token))] = role
+			}
+		}
+	}
+	add(interviewerTokens, ROLE_INTERVIEWER)
+	add(adminTokens, ROLE_ADMIN)
+	return StaffAuthenticator{roles: roles}
+}
+
+// Returns the role the token was given to, and whether it was given to anyone.
+func (a StaffAuthenticator) Role(token string) (string, bool) {
+	role, ok := a.roles[sha256.Sum256([]byte(token))]
+	return role, ok
+}
+
+// Whether the role has every one of the required roles.
+func RoleAllows(role string, required []string) bool {
+	for _, requiredRole := range required {
+		if !slices.Contains(roleGrants[role], requiredRole) {
+			return false
+		}
+	}
+	return true
+}
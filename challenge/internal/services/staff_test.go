@@ -0,0 +1,39 @@
+package services_test
+
+import (
+	"generate_technical_challenge_2025/internal/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaffAuthenticatorRole(t *testing.T) {
+	staff := services.CreateStaffAuthenticator([]string{"interviewer", "both", ""}, []string{"admin", "both"})
+
+	role, ok := staff.Role("interviewer")
+	assert.True(t, ok)
+	assert.Equal(t, services.ROLE_INTERVIEWER, role)
+
+	role, ok = staff.Role("admin")
+	assert.True(t, ok)
+	assert.Equal(t, services.ROLE_ADMIN, role)
+
+	// A token given to both roles is an admin token.
+	role, ok = staff.Role("both")
+	assert.True(t, ok)
+	assert.Equal(t, services.ROLE_ADMIN, role)
+
+	// Empty tokens must never authenticate, even when one was configured.
+	_, ok = staff.Role("")
+	assert.False(t, ok)
+	_, ok = staff.Role("unknown")
+	assert.False(t, ok)
+}
+
+func TestRoleAllows(t *testing.T) {
+	assert.True(t, services.RoleAllows(services.ROLE_INTERVIEWER, []string{services.ROLE_INTERVIEWER}))
+	assert.False(t, services.RoleAllows(services.ROLE_INTERVIEWER, []string{services.ROLE_ADMIN}))
+	assert.True(t, services.RoleAllows(services.ROLE_ADMIN, []string{services.ROLE_INTERVIEWER}))
+	assert.True(t, services.RoleAllows(services.ROLE_ADMIN, []string{services.ROLE_ADMIN}))
+	assert.False(t, services.RoleAllows("unknown", []string{services.ROLE_INTERVIEWER}))
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/transactions"
+	"generate_technical_challenge_2025/internal/utils"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Verifies that members own the email they registered with by emailing them one-time codes.
+type VerificationService interface {
+	SendCode(ctx context.Context, member *models.Member) error
+	Verify(memberID uuid.UUID, code string) error
+}
+
+// How long codes last, how often members can ask for one, and how many codes they can enter.
+type VerificationConfig struct {
+	CodeTTL     time.Duration
+	ResendDelay time.Duration // Members wait this long after a code was sent before another one is sent.
+	MaxAttempts int           // Codes that can be entered before the member needs a new one.
+}
+
+func CreateVerificationConfig(env utils.EnvConfig) VerificationConfig {
+	return VerificationConfig{
+		CodeTTL:     env.VERIFICATION_CODE_TTL,
+		ResendDelay: env.VERIFICATION_RESEND_DELAY,
+		MaxAttempts: env.VERIFICATION_MAX_ATTEMPTS,
+	}
+}
+
+var (
+	ErrCodeRecentlySent    = errors.New("a verification code was sent recently")
+	ErrInvalidCode         = errors.New("invalid verification code")
+	ErrCodeExpired         = errors.New("verification code expired")
+	ErrTooManyCodeAttempts = errors.New("too many verification code attempts")
+)
+
+// Number of digits in a verification code.
+const verificationCodeLength = 6
+
+type VerificationServiceImpl struct {
+	logger       *slog.Logger
+	transactions transactions.MemberTransactions
+	mailer       Mailer
+	config       VerificationConfig
+}
+
+// SendCode implements VerificationService.
+// Replaces the member's earlier code, failing with ErrCodeRecentlySent when it was sent less than
+// VerificationConfig.ResendDelay ago.
+func (v *VerificationServiceImpl) SendCode(ctx context.Context, member *models.Member) error {
+	previous, sent, err := v.transactions.GetVerificationCode(member.ID)
+	if err != nil {
+		return err
+	}
+	if sent && time.Since(previous.CreatedAt) < v.config.ResendDelay {
+		return ErrCodeRecentlySent
+	}
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(v.config.CodeTTL)
+	if err := v.transactions.UpsertVerificationCode(models.CreateVerificationCode(member.ID, hashVerificationCode(member.ID, code), expiresAt)); err != nil {
+		return err
+	}
+	email := Email{
+		To:      member.Email,
+		Subject: "Your Generate technical challenge verification code",
+		Body: fmt.Sprintf("Your verification code is %s. It expires in %s.\n\n"+
+			"If you did not register for the Generate technical challenge, you can ignore this email.",
+			code, v.config.CodeTTL.Round(time.Minute)),
+	}
+	if err := v.mailer.Send(ctx, email); err != nil {
+		v.logger.Error("Failed to send verification code", "member", member.ID, "error", err)
+		// Lets the member ask for another code straight away instead of waiting for one that never arrives.
+		return errors.Join(err, v.transactions.DeleteVerificationCode(member.ID))
+	}
+	return nil
+}
+
+// Verify implements VerificationService.
+// Marks the member as verified when the code matches their latest one.
+func (v *VerificationServiceImpl) Verify(memberID uuid.UUID, code string) error {
+	stored, sent, err := v.transactions.GetVerificationCode(memberID)
+	if err != nil {
+		return err
+	}
+	if !sent {
+		return ErrInvalidCode
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return ErrCodeExpired
+	}
+	counted, err := v.transactions.RecordVerificationAttempt(memberID, v.config.MaxAttempts)
+	if err != nil {
+		return err
+	}
+	if !counted {
+		return ErrTooManyCodeAttempts
+	}
+	if subtle.ConstantTimeCompare([]byte(hashVerificationCode(memberID, code)), []byte(stored.CodeHash)) != 1 {
+		return ErrInvalidCode
+	}
+	return v.transactions.VerifyMember(memberID)
+}
+
+// Random code of verificationCodeLength digits, keeping leading zeros.
+func generateVerificationCode() (string, error) {
+	upper := big.NewInt(1)
+	for range verificationCodeLength {
+		upper.Mul(upper, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, upper)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", verificationCodeLength, n), nil
+}
+
+// Salted with the member's ID so the same code hashes differently for every member.
+func hashVerificationCode(memberID uuid.UUID, code string) string {
+	hash := sha256.Sum256([]byte(memberID.String() + code))
+	return hex.EncodeToString(hash[:])
+}
+
+func CreateVerificationService(logger *slog.Logger, transactions transactions.MemberTransactions, mailer Mailer, config VerificationConfig) VerificationService {
+	return &VerificationServiceImpl{
+		logger:       logger,
+		transactions: transactions,
+		mailer:       mailer,
+		config:       config,
+	}
+}
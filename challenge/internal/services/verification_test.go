@@ -0,0 +1,146 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"generate_technical_challenge_2025/internal/database/models"
+	"generate_technical_challenge_2025/internal/services"
+	"generate_technical_challenge_2025/internal/transactions"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Keeps a single member's verification code in memory.
+type stubVerificationCodes struct {
+	transactions.MemberTransactions
+	code     *models.VerificationCode
+	verified bool
+}
+
+func (s *stubVerificationCodes) UpsertVerificationCode(code *models.VerificationCode) error {
+	s.code = code
+	return nil
+}
+
+func (s *stubVerificationCodes) GetVerificationCode(id uuid.UUID) (*models.VerificationCode, bool, error) {
+	return s.code, s.code != nil, nil
+}
+
+func (s *stubVerificationCodes) DeleteVerificationCode(id uuid.UUID) error {
+	s.code = nil
+	return nil
+}
+
+func (s *stubVerificationCodes) RecordVerificationAttempt(id uuid.UUID, maxAttempts int) (bool, error) {
+	if s.code == nil || s.code.Attempts >= maxAttempts {
+		return false, nil
+	}
+	s.code.Attempts++
+	return true, nil
+}
+
+func (s *stubVerificationCodes) VerifyMember(id uuid.UUID) error {
+	s.verified = true
+	s.code = nil
+	return nil
+}
+
+// Keeps every email sent, failing when err is set.
+type stubMailer struct {
+	sent []services.Email
+	err  error
+}
+
+func (m *stubMailer) Send(ctx context.Context, email services.Email) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, email)
+	return nil
+}
+
+var (
+	TEST_VERIFICATION_CONFIG = services.VerificationConfig{CodeTTL: time.Hour, ResendDelay: time.Minute, MaxAttempts: 3}
+	emailedCode              = regexp.MustCompile(`\b\d{6}\b`)
+)
+
+func testMember() *models.Member {
+	member := models.CreateMember("pending@northeastern.edu", "123456789", uuid.New(), 1)
+	member.ID = uuid.New()
+	return member
+}
+
+func TestVerifyWithEmailedCode(t *testing.T) {
+	stub, mailer, member := &stubVerificationCodes{}, &stubMailer{}, testMember()
+	verification := services.CreateVerificationService(LOGGER, stub, mailer, TEST_VERIFICATION_CONFIG)
+
+	assert.NoError(t, verification.SendCode(context.Background(), member))
+	assert.Len(t, mailer.sent, 1)
+	assert.Equal(t, member.Email, mailer.sent[0].To)
+	code := emailedCode.FindString(mailer.sent[0].Body)
+	assert.Len(t, code, 6)
+	assert.NotContains(t, stub.code.CodeHash, code, "only a hash of the code is stored")
+
+	assert.NoError(t, verification.Verify(member.ID, code))
+	assert.True(t, stub.verified)
+	assert.ErrorIs(t, verification.Verify(member.ID, code), services.ErrInvalidCode, "codes only work once")
+}
+
+func TestCodesAreNotResentTooOften(t *testing.T) {
+	stub, mailer, member := &stubVerificationCodes{}, &stubMailer{}, testMember()
+	verification := services.CreateVerificationService(LOGGER, stub, mailer, TEST_VERIFICATION_CONFIG)
+
+	assert.NoError(t, verification.SendCode(context.Background(), member))
+	assert.ErrorIs(t, verification.SendCode(context.Background(), member), services.ErrCodeRecentlySent)
+
+	stub.code.CreatedAt = time.Now().Add(-2 * time.Minute)
+	assert.NoError(t, verification.SendCode(context.Background(), member))
+	assert.Len(t, mailer.sent, 2)
+}
+
+func TestVerifyRejectsWrongAndExpiredCodes(t *testing.T) {
+	stub, mailer, member := &stubVerificationCodes{}, &stubMailer{}, testMember()
+	verification := services.CreateVerificationService(LOGGER, stub, mailer, TEST_VERIFICATION_CONFIG)
+
+	assert.ErrorIs(t, verification.Verify(member.ID, "123456"), services.ErrInvalidCode, "no code was sent")
+
+	assert.NoError(t, verification.SendCode(context.Background(), member))
+	code := emailedCode.FindString(mailer.sent[0].Body)
+	stub.code.ExpiresAt = time.Now().Add(-time.Second)
+	assert.ErrorIs(t, verification.Verify(member.ID, code), services.ErrCodeExpired)
+	assert.False(t, stub.verified)
+}
+
+func TestVerifyStopsAfterMaxAttempts(t *testing.T) {
+	stub, mailer, member := &stubVerificationCodes{}, &stubMailer{}, testMember()
+	verification := services.CreateVerificationService(LOGGER, stub, mailer, TEST_VERIFICATION_CONFIG)
+
+	assert.NoError(t, verification.SendCode(context.Background(), member))
+	code := emailedCode.FindString(mailer.sent[0].Body)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "000001"
+	}
+	for range TEST_VERIFICATION_CONFIG.MaxAttempts {
+		assert.ErrorIs(t, verification.Verify(member.ID, wrong), services.ErrInvalidCode)
+	}
+	assert.ErrorIs(t, verification.Verify(member.ID, code), services.ErrTooManyCodeAttempts)
+	assert.False(t, stub.verified)
+}
+
+func TestFailedEmailsCanBeResentStraightAway(t *testing.T) {
+	stub, member := &stubVerificationCodes{}, testMember()
+	mailer := &stubMailer{err: errors.New("smtp is down")}
+	verification := services.CreateVerificationService(LOGGER, stub, mailer, TEST_VERIFICATION_CONFIG)
+
+	assert.Error(t, verification.SendCode(context.Background(), member))
+	assert.Nil(t, stub.code)
+
+	mailer.err = nil
+	assert.NoError(t, verification.SendCode(context.Background(), member))
+	assert.Len(t, mailer.sent, 1)
+}
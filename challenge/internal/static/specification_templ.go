@@ -29,12 +29,64 @@ func ChallengePage() templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>Challenge</title><style>\n\t\t\t* {\n\t\t\t\tmargin: 0;\n\t\t\t\tpadding: 0;\n\t\t\t\tbox-sizing: border-box;\n\t\t\t}\n\t\t\t\n\t\t\tbody {\n\t\t\t\tfont-family: \"SF Mono\", \"Monaco\", \"Cascadia Code\", \"Roboto Mono\", \"Consolas\", \"Courier New\", monospace;\n\t\t\t\tbackground: linear-gradient(135deg, #0c0c0c 0%, #1a1a2e 50%, #16213e 100%);\n\t\t\t\tcolor: #00ff88;\n\t\t\t\tmin-height: 100vh;\n\t\t\t\tdisplay: flex;\n\t\t\t\talign-items: center;\n\t\t\t\tjustify-content: center;\n\t\t\t\tfont-size: 1.1rem;\n\t\t\t\tpadding: 2rem;\n\t\t\t\tposition: relative;\n\t\t\t}\n\t\t\t\n\t\t\t/* Simplified background effect - static instead of animated */\n\t\t\tbody::before {\n\t\t\t\tcontent: '';\n\t\t\t\tposition: absolute;\n\t\t\t\ttop: 0;\n\t\t\t\tleft: 0;\n\t\t\t\tright: 0;\n\t\t\t\tbottom: 0;\n\t\t\t\tbackground-image: \n\t\t\t\t\tradial-gradient(circle at 20% 80%, rgba(0, 255, 136, 0.08) 0%, transparent 50%),\n\t\t\t\t\tradial-gradient(circle at 80% 20%, rgba(0, 191, 255, 0.08) 0%, transparent 50%),\n\t\t\t\t\tradial-gradient(circle at 40% 40%, rgba(138, 43, 226, 0.08) 0%, transparent 50%);\n\t\t\t\topacity: 0.5;\n\t\t\t\tpointer-events: none;\n\t\t\t}\n\t\t\t\n\t\t\t/* Reduced animations - only on hover/focus for better performance */\n\t\t\t@keyframes subtleGlow {\n\t\t\t\t0%, 100% { \n\t\t\t\t\tbox-shadow: 0 0 15px rgba(0, 255, 136, 0.2);\n\t\t\t\t}\n\t\t\t\t50% { \n\t\t\t\t\tbox-shadow: 0 0 20px rgba(0, 255, 136, 0.3);\n\t\t\t\t}\n\t\t\t}\n\t\t\t\n\t\t\t.card {\n\t\t\t\ttext-align: start;\n\t\t\t\tpadding: 3rem;\n\t\t\t\tbackground: linear-gradient(135deg, rgba(0, 0, 0, 0.85) 0%, rgba(26, 26, 46, 0.9) 100%);\n\t\t\t\tborder-radius: 20px;\n\t\t\t\tborder: 2px solid rgba(0, 255, 136, 0.4);\n\t\t\t\tmax-width: 1000px;\n\t\t\t\twidth: 100%;\n\t\t\t\theight: calc(100vh - 4rem);\n\t\t\t\tline-height: 1.6;\n\t\t\t\tposition: relative;\n\t\t\t\tbackdrop-filter: blur(8px);\n\t\t\t\toverflow-y: auto;\n\t\t\t\t/* Static glow instead of animated */\n\t\t\t\tbox-shadow: 0 0 20px rgba(0, 255, 136, 0.15);\n\t\t\t}\n\t\t\t\n\t\t\t/* Hover effect for subtle interactivity */\n\t\t\t.card:hover {\n\t\t\t\tborder-color: rgba(0, 255, 136, 0.6);\n\t\t\t\tbox-shadow: 0 0 25px rgba(0, 255, 136, 0.25);\n\t\t\t\ttransition: all 0.3s ease;\n\t\t\t}\n\t\t\t\n\t\t\th1 { \n\t\t\t\tfont-size: 2.2rem; \n\t\t\t\tmargin-bottom: 2rem;\n\t\t\t\tmargin-top: 1rem;\n\t\t\t\tcolor: #00ff88;\n\t\t\t\ttext-transform: uppercase;\n\t\t\t\tletter-spacing: 2px;\n\t\t\t\tfont-weight: bold;\n\t\t\t\t/* Static glow */\n\t\t\t\ttext-shadow: 0 0 10px rgba(0, 255, 136, 0.6);\n\t\t\t}\n\t\t\t\n\t\t\tp { \n\t\t\t\tfont-size: 1.2rem;\n\t\t\t\tcolor: #a0ffcc;\n\t\t\t\tmargin-bottom: 1.5rem;\n\t\t\t\ttext-shadow: 0 0 3px rgba(0, 255, 136, 0.2);\n\t\t\t}\n\t\t\t\n\t\t\th2 {\n\t\t\t\tfont-size: 1.6rem;\n\t\t\t\tmargin-top: 2rem;\n\t\t\t\tmargin-bottom: 1rem;\n\t\t\t\tcolor: #00bfff;\n\t\t\t\ttext-shadow: 0 0 8px rgba(0, 191, 255, 0.5);\n\t\t\t\ttext-transform: uppercase;\n\t\t\t\tletter-spacing: 1px;\n\t\t\t}\n\t\t\t\n\t\t\th3 {\n\t\t\t\tfont-size: 1.4rem;\n\t\t\t\tmargin-top: 1.5rem;\n\t\t\t\tmargin-bottom: 0.75rem;\n\t\t\t\tcolor: #8a2be2;\n\t\t\t\ttext-shadow: 0 0 6px rgba(138, 43, 226, 0.5);\n\t\t\t\ttext-transform: uppercase;\n\t\t\t}\n\n\t\t\th4 {\n\t\t\t\tfont-size: 1.2rem;\n\t\t\t\tmargin-top: 1rem;\n\t\t\t\tmargin-bottom: 0.75rem;\n\t\t\t\tcolor: #2a8be1;\n\t\t\t\ttext-shadow: 0 0 6px rgba(42, 139, 225, 0.5);\n\t\t\t\ttext-transform: uppercase;\n\t\t\t}\n\t\t\t\n\t\t\tpre {\n\t\t\t\tbackground: linear-gradient(135deg, rgba(0, 0, 0, 0.9) 0%, rgba(13, 13, 26, 0.95) 100%);\n\t\t\t\tborder: 1px solid rgba(0, 191, 255, 0.3);\n\t\t\t\tborder-radius: 12px;\n\t\t\t\tpadding: 1.5rem;\n\t\t\t\tmargin: 2rem 0;\n\t\t\t\ttext-align: left;\n\t\t\t\toverflow-x: auto;\n\t\t\t\tposition: relative;\n\t\t\t\t/* Static glow */\n\t\t\t\tbox-shadow: 0 0 10px rgba(0, 191, 255, 0.15);\n\t\t\t}\n\t\t\t\n\t\t\t/* Hover effect for code blocks */\n\t\t\tpre:hover {\n\t\t\t\tborder-color: rgba(0, 191, 255, 0.5);\n\t\t\t\tbox-shadow: 0 0 15px rgba(0, 191, 255, 0.25);\n\t\t\t\ttransition: all 0.3s ease;\n\t\t\t}\n\t\t\t\n\t\t\tcode {\n\t\t\t\tfont-family: \"SF Mono\", \"Monaco\", \"Cascadia Code\", \"Roboto Mono\", \"Consolas\", \"Courier New\", monospace;\n\t\t\t\tcolor: #00ff88;\n\t\t\t\ttext-shadow: 0 0 2px rgba(0, 255, 136, 0.4);\n\t\t\t\tline-height: 1.4;\n\t\t\t}\n\t\t\t\n\t\t\t.keyword {\n\t\t\t\tcolor: #ff6b9d;\n\t\t\t\ttext-shadow: 0 0 3px rgba(255, 107, 157, 0.4);\n\t\t\t}\n\t\t\t\n\t\t\t.string {\n\t\t\t\tcolor: #ffd700;\n\t\t\t\ttext-shadow: 0 0 3px rgba(255, 215, 0, 0.4);\n\t\t\t}\n\t\t\t\n\t\t\t.comment {\n\t\t\t\tcolor: #8a2be2;\n\t\t\t\ttext-shadow: 0 0 3px rgba(138, 43, 226, 0.4);\n\t\t\t\tfont-style: italic;\n\t\t\t}\n\t\t\t\n\t\t\t.function {\n\t\t\t\tcolor: #00bfff;\n\t\t\t\ttext-shadow: 0 0 3px rgba(0, 191, 255, 0.4);\n\t\t\t}\n\t\t\t\n\t\t\ta {\n\t\t\t\tcolor: #00ff88;\n\t\t\t\ttext-decoration: none;\n\t\t\t\tposition: relative;\n\t\t\t\tpadding: 0.5rem 1rem;\n\t\t\t\tborder: 1px solid rgba(0, 255, 136, 0.4);\n\t\t\t\tborder-radius: 8px;\n\t\t\t\tbackground: linear-gradient(135deg, rgba(0, 255, 136, 0.08) 0%, rgba(0, 191, 255, 0.08) 100%);\n\t\t\t\ttext-shadow: 0 0 5px rgba(0, 255, 136, 0.6);\n\t\t\t\tletter-spacing: 1px;\n\t\t\t\ttext-transform: uppercase;\n\t\t\t\tfont-weight: bold;\n\t\t\t\ttransition: all 0.3s ease;\n\t\t\t\tdisplay: inline-block;\n\t\t\t\tmargin: 1rem 0;\n\t\t\t}\n\t\t\t\n\t\t\ta:hover {\n\t\t\t\tcolor: #fff;\n\t\t\t\tbackground: linear-gradient(135deg, rgba(0, 255, 136, 0.2) 0%, rgba(0, 191, 255, 0.2) 100%);\n\t\t\t\tborder-color: rgba(0, 255, 136, 0.7);\n\t\t\t\tbox-shadow: 0 0 15px rgba(0, 255, 136, 0.3);\n\t\t\t\ttext-shadow: 0 0 8px rgba(0, 255, 136, 0.8);\n\t\t\t\ttransform: translateY(-1px);\n\t\t\t}\n\t\t\t\n\t\t\ta:active {\n\t\t\t\ttransform: translateY(0px);\n\t\t\t}\n\t\t\t\n\t\t\ta::before {\n\t\t\t\tcontent: '◊ ';\n\t\t\t\tcolor: #00bfff;\n\t\t\t\ttext-shadow: 0 0 5px rgba(0, 191, 255, 0.6);\n\t\t\t}\n\t\t\t\n\t\t\ta::after {\n\t\t\t\tcontent: ' ◊';\n\t\t\t\tcolor: #00bfff;\n\t\t\t\ttext-shadow: 0 0 5px rgba(0, 191, 255, 0.6);\n\t\t\t}\n\n\t\t\t/* Reduce motion for users who prefer it */\n\t\t\t@media (prefers-reduced-motion: reduce) {\n\t\t\t\t* {\n\t\t\t\t\tanimation-duration: 0.01ms !important;\n\t\t\t\t\tanimation-iteration-count: 1 !important;\n\t\t\t\t\ttransition-duration: 0.01ms !important;\n\t\t\t\t}\n\t\t\t}\n\t\t</style></head><body><div class=\"card\"><h1>Generate Technical Member Challenge Fall 2025</h1><p>You are tasked with completing one technical deliverable for your Generate Software Engineer interview. The deliverable consists of completing one technical challenge prior to your interview, and then conducting a walkthrough of your solution during your interview. Your walkthrough must include screensharing of your code, you may be asked to submit your solution, and you are not expected to prepare additional visuals or writings.</p><strong>Note: you need to register, and if you do a backend challenge, submit your attempt as many times as you want. If you do the frontend challenge, there is no submission, but you will still make requests to our data endpoint. You can register and find the submission/data endpoints on the API Specification, linked at the bottom of this page.</strong><h1>Backend Challenges</h1>Generate will be introducing tracks for the fall challenges, with track 1 being relatively easier than track 2. Tracks are meant to provide newer and experienced members more options to showcase their technial abilities. Please choose one challenge below to complete for your interview. In both challenges, a lower score is better. A score of 0 is a perfect score, and a score of -1 is a failure/ungradable submission.<h2>Track 1: ngrok Challenge</h2><p>You are the alien leader of an advancing invasion fleet, and your species has catalogued thousands of intelligent life forms across the galaxy for strategic assessment.</p><p>You are tasked with implementing a robust database API to store, retrieve, and filter alien species data.</p><p>You need to build a server to handle storing and retrieving alien data. The server should support:</p><p>GET /healthcheck</p><p>We will query this endpoint to determine if your server is alive and ready to receive connections.</p><p>POST /api/aliens</p><p>We will query this endpoint to send alien data. The alien model is DetailedAlien, outlined in the API specification, and the aliens will be sent in an array.</p><p>GET /api/aliens</p><p>We will query this endpoint to retrieve alien data. This should support the following filters as query parameters:</p><p>\"spd_lte\"</p><p>\"spd_gte\"</p><p>\"atk_lte\"</p><p>\"atk_gte\"</p><p>\"hp_gte\"</p><p>\"hp_lte\"</p><p>\"type\"</p><p>DELETE /api/aliens</p><p>We will query this endpoint at the beginning of every test to clear your alien data. This is on setup, not on teardown.</p><p>To submit your attempt, expose your server using ngrok and submit that URL. Read the ngrok documentation here:</p><div style=\"text-align: center;\"><a href=\"https://ngrok.com/\">ngrok</a></div><h2>Track 2: Alien Invasion Challenge</h2><p>Aliens are attacking our hometown! It is up to you as the commander to protect your home from being destroyed by the alien invasion.</p><h3>Technical Specification</h3><p>Our intelligence agencies have been able to identify the key characteristics of the impending aliens and have relayed them to you.</p><p>ALIEN is a JSON Object with HP and ATK. </p><p>INVASION is a JSON ARRAY of ALIEN. </p><p>POWER is the sum of the ALIEN remaining HP and ATK. </p><p>EDIT: When sorting the aliens POWER, if two aliens have the same power then sort by whichever alien has the SMALLER HP. That is for example given an array of aliens like this: </p><pre><code>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>Challenge</title><style>\n\t\t\t* {\n\t\t\t\tmargin: 0;\n\t\t\t\tpadding: 0;\n\t\t\t\tbox-sizing: border-box;\n\t\t\t}\n\t\t\t\n\t\t\tbody {\n\t\t\t\tfont-family: \"SF Mono\", \"Monaco\", \"Cascadia Code\", \"Roboto Mono\", \"Consolas\", \"Courier New\", monospace;\n\t\t\t\tbackground: linear-gradient(135deg, #0c0c0c 0%, #1a1a2e 50%, #16213e 100%);\n\t\t\t\tcolor: #00ff88;\n\t\t\t\tmin-height: 100vh;\n\t\t\t\tdisplay: flex;\n\t\t\t\talign-items: center;\n\t\t\t\tjustify-content: center;\n\t\t\t\tfont-size: 1.1rem;\n\t\t\t\tpadding: 2rem;\n\t\t\t\tposition: relative;\n\t\t\t}\n\t\t\t\n\t\t\t/* Simplified background effect - static instead of animated */\n\t\t\tbody::before {\n\t\t\t\tcontent: '';\n\t\t\t\tposition: absolute;\n\t\t\t\ttop: 0;\n\t\t\t\tleft: 0;\n\t\t\t\tright: 0;\n\t\t\t\tbottom: 0;\n\t\t\t\tbackground-image: \n\t\t\t\t\tradial-gradient(circle at 20% 80%, rgba(0, 255, 136, 0.08) 0%, transparent 50%),\n\t\t\t\t\tradial-gradient(circle at 80% 20%, rgba(0, 191, 255, 0.08) 0%, transparent 50%),\n\t\t\t\t\tradial-gradient(circle at 40% 40%, rgba(138, 43, 226, 0.08) 0%, transparent 50%);\n\t\t\t\topacity: 0.5;\n\t\t\t\tpointer-events: none;\n\t\t\t}\n\t\t\t\n\t\t\t/* Reduced animations - only on hover/focus for better performance */\n\t\t\t@keyframes subtleGlow {\n\t\t\t\t0%, 100% { \n\t\t\t\t\tbox-shadow: 0 0 15px rgba(0, 255, 136, 0.2);\n\t\t\t\t}\n\t\t\t\t50% { \n\t\t\t\t\tbox-shadow: 0 0 20px rgba(0, 255, 136, 0.3);\n\t\t\t\t}\n\t\t\t}\n\t\t\t\n\t\t\t.card {\n\t\t\t\ttext-align: start;\n\t\t\t\tpadding: 3rem;\n\t\t\t\tbackground: linear-gradient(135deg, rgba(0, 0, 0, 0.85) 0%, rgba(26, 26, 46, 0.9) 100%);\n\t\t\t\tborder-radius: 20px;\n\t\t\t\tborder: 2px solid rgba(0, 255, 136, 0.4);\n\t\t\t\tmax-width: 1000px;\n\t\t\t\twidth: 100%;\n\t\t\t\theight: calc(100vh - 4rem);\n\t\t\t\tline-height: 1.6;\n\t\t\t\tposition: relative;\n\t\t\t\tbackdrop-filter: blur(8px);\n\t\t\t\toverflow-y: auto;\n\t\t\t\t/* Static glow instead of animated */\n\t\t\t\tbox-shadow: 0 0 20px rgba(0, 255, 136, 0.15);\n\t\t\t}\n\t\t\t\n\t\t\t/* Hover effect for subtle interactivity */\n\t\t\t.card:hover {\n\t\t\t\tborder-color: rgba(0, 255, 136, 0.6);\n\t\t\t\tbox-shadow: 0 0 25px rgba(0, 255, 136, 0.25);\n\t\t\t\ttransition: all 0.3s ease;\n\t\t\t}\n\t\t\t\n\t\t\th1 { \n\t\t\t\tfont-size: 2.2rem; \n\t\t\t\tmargin-bottom: 2rem;\n\t\t\t\tmargin-top: 1rem;\n\t\t\t\tcolor: #00ff88;\n\t\t\t\ttext-transform: uppercase;\n\t\t\t\tletter-spacing: 2px;\n\t\t\t\tfont-weight: bold;\n\t\t\t\t/* Static glow */\n\t\t\t\ttext-shadow: 0 0 10px rgba(0, 255, 136, 0.6);\n\t\t\t}\n\t\t\t\n\t\t\tp { \n\t\t\t\tfont-size: 1.2rem;\n\t\t\t\tcolor: #a0ffcc;\n\t\t\t\tmargin-bottom: 1.5rem;\n\t\t\t\ttext-shadow: 0 0 3px rgba(0, 255, 136, 0.2);\n\t\t\t}\n\t\t\t\n\t\t\th2 {\n\t\t\t\tfont-size: 1.6rem;\n\t\t\t\tmargin-top: 2rem;\n\t\t\t\tmargin-bottom: 1rem;\n\t\t\t\tcolor: #00bfff;\n\t\t\t\ttext-shadow: 0 0 8px rgba(0, 191, 255, 0.5);\n\t\t\t\ttext-transform: uppercase;\n\t\t\t\tletter-spacing: 1px;\n\t\t\t}\n\t\t\t\n\t\t\th3 {\n\t\t\t\tfont-size: 1.4rem;\n\t\t\t\tmargin-top: 1.5rem;\n\t\t\t\tmargin-bottom: 0.75rem;\n\t\t\t\tcolor: #8a2be2;\n\t\t\t\ttext-shadow: 0 0 6px rgba(138, 43, 226, 0.5);\n\t\t\t\ttext-transform: uppercase;\n\t\t\t}\n\n\t\t\th4 {\n\t\t\t\tfont-size: 1.2rem;\n\t\t\t\tmargin-top: 1rem;\n\t\t\t\tmargin-bottom: 0.75rem;\n\t\t\t\tcolor: #2a8be1;\n\t\t\t\ttext-shadow: 0 0 6px rgba(42, 139, 225, 0.5);\n\t\t\t\ttext-transform: uppercase;\n\t\t\t}\n\t\t\t\n\t\t\tpre {\n\t\t\t\tbackground: linear-gradient(135deg, rgba(0, 0, 0, 0.9) 0%, rgba(13, 13, 26, 0.95) 100%);\n\t\t\t\tborder: 1px solid rgba(0, 191, 255, 0.3);\n\t\t\t\tborder-radius: 12px;\n\t\t\t\tpadding: 1.5rem;\n\t\t\t\tmargin: 2rem 0;\n\t\t\t\ttext-align: left;\n\t\t\t\toverflow-x: auto;\n\t\t\t\tposition: relative;\n\t\t\t\t/* Static glow */\n\t\t\t\tbox-shadow: 0 0 10px rgba(0, 191, 255, 0.15);\n\t\t\t}\n\t\t\t\n\t\t\t/* Hover effect for code blocks */\n\t\t\tpre:hover {\n\t\t\t\tborder-color: rgba(0, 191, 255, 0.5);\n\t\t\t\tbox-shadow: 0 0 15px rgba(0, 191, 255, 0.25);\n\t\t\t\ttransition: all 0.3s ease;\n\t\t\t}\n\t\t\t\n\t\t\tcode {\n\t\t\t\tfont-family: \"SF Mono\", \"Monaco\", \"Cascadia Code\", \"Roboto Mono\", \"Consolas\", \"Courier New\", monospace;\n\t\t\t\tcolor: #00ff88;\n\t\t\t\ttext-shadow: 0 0 2px rgba(0, 255, 136, 0.4);\n\t\t\t\tline-height: 1.4;\n\t\t\t}\n\t\t\t\n\t\t\t.keyword {\n\t\t\t\tcolor: #ff6b9d;\n\t\t\t\ttext-shadow: 0 0 3px rgba(255, 107, 157, 0.4);\n\t\t\t}\n\t\t\t\n\t\t\t.string {\n\t\t\t\tcolor: #ffd700;\n\t\t\t\ttext-shadow: 0 0 3px rgba(255, 215, 0, 0.4);\n\t\t\t}\n\t\t\t\n\t\t\t.comment {\n\t\t\t\tcolor: #8a2be2;\n\t\t\t\ttext-shadow: 0 0 3px rgba(138, 43, 226, 0.4);\n\t\t\t\tfont-style: italic;\n\t\t\t}\n\t\t\t\n\t\t\t.function {\n\t\t\t\tcolor: #00bfff;\n\t\t\t\ttext-shadow: 0 0 3px rgba(0, 191, 255, 0.4);\n\t\t\t}\n\t\t\t\n\t\t\ta {\n\t\t\t\tcolor: #00ff88;\n\t\t\t\ttext-decoration: none;\n\t\t\t\tposition: relative;\n\t\t\t\tpadding: 0.5rem 1rem;\n\t\t\t\tborder: 1px solid rgba(0, 255, 136, 0.4);\n\t\t\t\tborder-radius: 8px;\n\t\t\t\tbackground: linear-gradient(135deg, rgba(0, 255, 136, 0.08) 0%, rgba(0, 191, 255, 0.08) 100%);\n\t\t\t\ttext-shadow: 0 0 5px rgba(0, 255, 136, 0.6);\n\t\t\t\tletter-spacing: 1px;\n\t\t\t\ttext-transform: uppercase;\n\t\t\t\tfont-weight: bold;\n\t\t\t\ttransition: all 0.3s ease;\n\t\t\t\tdisplay: inline-block;\n\t\t\t\tmargin: 1rem 0;\n\t\t\t}\n\t\t\t\n\t\t\ta:hover {\n\t\t\t\tcolor: #fff;\n\t\t\t\tbackground: linear-gradient(135deg, rgba(0, 255, 136, 0.2) 0%, rgba(0, 191, 255, 0.2) 100%);\n\t\t\t\tborder-color: rgba(0, 255, 136, 0.7);\n\t\t\t\tbox-shadow: 0 0 15px rgba(0, 255, 136, 0.3);\n\t\t\t\ttext-shadow: 0 0 8px rgba(0, 255, 136, 0.8);\n\t\t\t\ttransform: translateY(-1px);\n\t\t\t}\n\t\t\t\n\t\t\ta:active {\n\t\t\t\ttransform: translateY(0px);\n\t\t\t}\n\t\t\t\n\t\t\ta::before {\n\t\t\t\tcontent: '◊ ';\n\t\t\t\tcolor: #00bfff;\n\t\t\t\ttext-shadow: 0 0 5px rgba(0, 191, 255, 0.6);\n\t\t\t}\n\t\t\t\n\t\t\ta::after {\n\t\t\t\tcontent: ' ◊';\n\t\t\t\tcolor: #00bfff;\n\t\t\t\ttext-shadow: 0 0 5px rgba(0, 191, 255, 0.6);\n\t\t\t}\n\n\t\t\t/* Reduce motion for users who prefer it */\n\t\t\t@media (prefers-reduced-motion: reduce) {\n\t\t\t\t* {\n\t\t\t\t\tanimation-duration: 0.01ms !important;\n\t\t\t\t\tanimation-iteration-count: 1 !important;\n\t\t\t\t\ttransition-duration: 0.01ms !important;\n\t\t\t\t}\n\t\t\t}\n\t\t</style></head><body><div class=\"card\"><h1>Generate Technical Member Challenge Fall 2025</h1><p>You are tasked with completing one technical deliverable for your Generate Software Engineer interview. The deliverable consists of completing one technical challenge prior to your interview, and then conducting a walkthrough of your solution during your interview. Your walkthrough must include screensharing of your code, you may be asked to submit your solution, and you are not expected to prepare additional visuals or writings.</p><strong>Note: you need to register, and if you do a backend challenge, submit your attempt. Each challenge has a limited number of submissions, so test your solution before submitting it; <code>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var2 string
-		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(
+		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs("GET /api/v1/member/{id}/attempts")
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/static/specification.templ`, Line: 232, Col: 233}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</code> shows how many you have left, and <code>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var3 string
+		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs("GET /api/v1/member/{id}/status")
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/static/specification.templ`, Line: 232, Col: 317}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "</code> everything you have submitted so far. If you do the frontend challenge, there is no submission, but you will still make requests to our data endpoint. You can register and find the submission/data endpoints on the API Specification, linked at the bottom of this page.</strong><p>Registering emails a one-time code to your Northeastern address. Exchange it for your id with <code>POST /api/v1/member/verify</code>; you cannot submit until you have verified your email. If your code expired, or you lost your id, <code>POST /api/v1/member/code</code> emails you a new one.</p><p>You register into a cohort, the recruiting cycle you are applying in. Challenges can only be fetched and submitted while your cohort is open, and only the challenges listed for your cohort are scored. If more than one cohort is open, pass its name as <code>cohort</code> when registering; <code>GET /api/v1/cohorts</code> lists every cohort.</p><h1>Backend Challenges</h1>Generate will be introducing tracks for the fall challenges, with track 1 being relatively easier than track 2. Tracks are meant to provide newer and experienced members more options to showcase their technial abilities. Please choose one challenge below to complete for your interview. In both challenges, a lower score is better. A score of 0 is a perfect score, and a score of -1 is a failure/ungradable submission. Your best valid score shows up on <code>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var4 string
+		templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs("GET /api/v1/leaderboard?challenge=...")
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/static/specification.templ`, Line: 238, Col: 82}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</code> under a generated handle, never your email. The leaderboard is refreshed periodically, so new scores can take a moment to appear.<h2>Track 1: ngrok Challenge</h2><p>You are the alien leader of an advancing invasion fleet, and your species has catalogued thousands of intelligent life forms across the galaxy for strategic assessment.</p><p>You are tasked with implementing a robust database API to store, retrieve, and filter alien species data.</p><p>You need to build a server to handle storing and retrieving alien data. The server should support:</p><p>GET /healthcheck</p><p>We will query this endpoint to determine if your server is alive and ready to receive connections.</p><p>POST /api/aliens</p><p>We will query this endpoint to send alien data. The alien model is DetailedAlien, outlined in the API specification, and the aliens will be sent in an array.</p><p>Respond with a 400 and store none of the aliens if the body is not an array, or if any alien is missing its id, has a negative \"spd\", \"atk\" or \"hp\", has a \"type\" other than \"Regular\", \"Elite\" or \"Boss\", or has the same id as an alien that was already posted.</p><p>GET /api/aliens</p><p>We will query this endpoint to retrieve alien data. This should support the following filters as query parameters:</p><p>\"spd_lte\"</p><p>\"spd_gte\"</p><p>\"atk_lte\"</p><p>\"atk_gte\"</p><p>\"hp_gte\"</p><p>\"hp_lte\"</p><p>\"type\"</p><p>It should also support pagination with \"limit\" and \"offset\", applied in the order the aliens were posted, and sorting with \"sort\" (one of \"spd\", \"atk\", \"hp\") and \"order\" (one of \"asc\", \"desc\").</p><p>Every filter given must hold, and unknown query parameters should be ignored. Respond with a 400 if a supported parameter is given more than once, or has a value that is not allowed (e.g. \"spd_gte=high\" or \"limit=-1\").</p><p>DELETE /api/aliens</p><p>We will query this endpoint at the beginning of every test to clear your alien data. This is on setup, not on teardown.</p><p>Deleting when there are no aliens left should still succeed.</p><p>GET /api/aliens/:id</p><p>We will query this endpoint to retrieve a single alien, and expect a 404 if it does not exist.</p><p>PATCH /api/aliens/:id</p><p>We will query this endpoint with some of an alien's fields, and expect the updated alien back.</p><p>DELETE /api/aliens/:id</p><p>We will query this endpoint to delete a single alien.</p><p>We will also send several POST and GET requests to your server at the same time. Every posted alien should be stored exactly once, and a GET should never return an alien twice.</p><p>To submit your attempt, expose your server using ngrok and submit that URL. Read the ngrok documentation here:</p><div style=\"text-align: center;\"><a href=\"https://ngrok.com/\">ngrok</a></div><p>Submitting queues a grading job and returns its jobId right away. Poll ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var5 string
+		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs("GET /api/v1/challenge/backend/{id}/ngrok/jobs/{jobId}")
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/static/specification.templ`, Line: 274, Col: 135}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, " until the job is done to see your score and the result of every check.</p><p>We also time how long your server takes to respond to our GET requests. A fast server can earn back a few lost points, and a slow one (e.g. one that scans every alien for every filter) loses some.</p><h2>Track 2: Alien Invasion Challenge</h2><p>Aliens are attacking our hometown! It is up to you as the commander to protect your home from being destroyed by the alien invasion.</p><h3>Technical Specification</h3><p>Our intelligence agencies have been able to identify the key characteristics of the impending aliens and have relayed them to you.</p><p>ALIEN is a JSON Object with HP and ATK. </p><p>INVASION is a JSON ARRAY of ALIEN. </p><p>POWER is the sum of the ALIEN remaining HP and ATK. </p><p>EDIT: When sorting the aliens POWER, if two aliens have the same power then sort by whichever alien has the SMALLER HP. That is for example given an array of aliens like this: </p><pre><code>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var6 string
+		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(
 			`// Given these aliens
 {Hp: 3, Atk: 3}, // 6 Power
 {Hp: 2, Atk: 3}, // 5 Power
@@ -69,13 +121,13 @@ func ChallengePage() templ.Component {
 {Hp: 2, Atk: 1}, // 3 Power
 {Hp: 2, Atk: 1}  // 3 Power`)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/static/specification.templ`, Line: 306, Col: 28}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/static/specification.templ`, Line: 322, Col: 28}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</code></pre><p>The formal API specification may be used to view these definitions in greater detail.</p><h4>A Command is one of </h4><p>\"volley\" - Targets the number of aliens sorted by descending POWER decided by taking your remaining HP modulo the remaining number of aliens, dealing 1 HP.</p><p>\"focusedVolley\" - Targets half of the remaining aliens, rounded up and sorted by descending POWER, dealing 2 HP.</p><p>\"focusedShot\" - Targets the alien with the highest POWER, killing them instantly.</p><h4>Additional Specifications </h4><p><strong>EDIT:</strong> The sequence of events must follow in this order, first you the commander will attack the aliens, then the surviving aliens will attack your remaining HP with each alien dealing damage equal to their <strong><em>ATK power</em></strong>. </p><p>An Invasion is over if and only if your remaining HP &le; 0 or there are no more invading aliens left.</p><p>The testing oracle will prioritize states in this order: 1, the least remaining aliens left, 2. the highest remaining hp left over, and 3. the least number of commands used.</p><p>Given an array of distinct alien invasions from our challenge server and given the commands available to you, find a state in which maximizes the aliens killed, maximizes the remaining hp left over, and minimizes the commands provided. (In this order) </p><p>NOTE: The challenge server will send multiple invasions, you are expected to return each solved invasion with the corresponding challenge ID, in any order. Your scores will be averaged and returned to you by the oracle. </p><h1>Frontend Challenge</h1><p>You are an alien in a fleet, proficient in frontend development, and your alien leader has asked you to build a dashboard for the fleet.</p><p>Fortunately, a fellow alien has created a design for the dashboard, so aim to match that it closely as possible.</p><p>Moreover, another alien has built an alien database and retrieval endpoint, outlined on the API specification linked below.</p><div style=\"text-align: center;\"><a href=\"https://www.figma.com/design/reKpbILCizcrTjnrBn6hzU/Generate-Coding-Challenge?node-id=1071-381&p=f\">Design</a></div><p>You are tasked with matching the design as closely as possible, and populating it with data fetched from the alien database.</p><div style=\"text-align: center;\"><a href=\"/\">Find the API Specification Here.</a></div><p>If you have any questions or concerns, reach out to the Chiefs of Software Zach and Stone at croft.z@northeastern.edu and liu.sto@northeastern.edu.</p></div></body></html>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</code></pre><p>The formal API specification may be used to view these definitions in greater detail.</p><h4>A Command is one of </h4><p>\"volley\" - Targets the number of aliens sorted by descending POWER decided by taking your remaining HP modulo the remaining number of aliens, dealing 1 HP.</p><p>\"focusedVolley\" - Targets half of the remaining aliens, rounded up and sorted by descending POWER, dealing 2 HP.</p><p>\"focusedShot\" - Targets the alien with the highest POWER, killing them instantly.</p><h4>Additional Specifications </h4><p><strong>EDIT:</strong> The sequence of events must follow in this order, first you the commander will attack the aliens, then the surviving aliens will attack your remaining HP with each alien dealing damage equal to their <strong><em>ATK power</em></strong>. </p><p>An Invasion is over if and only if your remaining HP &le; 0 or there are no more invading aliens left.</p><p>The testing oracle will prioritize states in this order: 1, the least remaining aliens left, 2. the highest remaining hp left over, and 3. the least number of commands used.</p><p>Given an array of distinct alien invasions from our challenge server and given the commands available to you, find a state in which maximizes the aliens killed, maximizes the remaining hp left over, and minimizes the commands provided. (In this order) </p><p>NOTE: The challenge server will send multiple invasions, you are expected to return each solved invasion with the corresponding challenge ID, in any order. Your scores will be averaged and returned to you by the oracle. </p><h1>Frontend Challenge</h1><p>You are an alien in a fleet, proficient in frontend development, and your alien leader has asked you to build a dashboard for the fleet.</p><p>Fortunately, a fellow alien has created a design for the dashboard, so aim to match that it closely as possible.</p><p>Moreover, another alien has built an alien database and retrieval endpoint, outlined on the API specification linked below.</p><div style=\"text-align: center;\"><a href=\"https://www.figma.com/design/reKpbILCizcrTjnrBn6hzU/Generate-Coding-Challenge?node-id=1071-381&p=f\">Design</a></div><p>You are tasked with matching the design as closely as possible, and populating it with data fetched from the alien database.</p><div style=\"text-align: center;\"><a href=\"/\">Find the API Specification Here.</a></div><p>If you have any questions or concerns, reach out to the Chiefs of Software Zach and Stone at croft.z@northeastern.edu and liu.sto@northeastern.edu.</p></div></body></html>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -1,12 +1,21 @@
 package transactions
 
 import (
+	"generate_technical_challenge_2025/internal/database/models"
 	"log/slog"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-type ChallengeTransactions interface{}
+type ChallengeTransactions interface {
+	SaveAlienChallengeSolutionsForMember(*models.Score, *models.Submission) error
+	InsertNgrokJob(*models.NgrokJob) error
+	GetNgrokJob(uuid.UUID) (*models.NgrokJob, bool, error)
+	GetNgrokJobsByStatus(string) ([]models.NgrokJob, error)
+	UpdateNgrokJobStatus(uuid.UUID, string, string) error
+	CompleteNgrokJob(*models.NgrokJob, *models.Score) error
+}
 
 type ChallengeTransactionsImpl struct {
 	logger *slog.Logger
@@ -14,6 +23,57 @@ type ChallengeTransactionsImpl struct {
 }
 
 // SaveAlienChallengeSolutionsForMember implements ChallengeTransactions.
+// The score and every submitted wave are written together, or not at all.
+func (c ChallengeTransactionsImpl) SaveAlienChallengeSolutionsForMember(score *models.Score, submission *models.Submission) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(score).Error; err != nil {
+			return err
+		}
+		return tx.Create(submission).Error
+	})
+}
+
+// InsertNgrokJob implements ChallengeTransactions.
+func (c ChallengeTransactionsImpl) InsertNgrokJob(job *models.NgrokJob) error {
+	return c.db.Create(job).Error
+}
+
+// GetNgrokJob implements ChallengeTransactions.
+// Returns the job along with its score, and whether the job exists.
+func (c ChallengeTransactionsImpl) GetNgrokJob(id uuid.UUID) (*models.NgrokJob, bool, error) {
+	var job models.NgrokJob
+	res := c.db.Preload("Score").Where("id = ?", id).Limit(1).Find(&job)
+	if res.Error != nil {
+		return nil, false, res.Error
+	}
+	return &job, res.RowsAffected > 0, nil
+}
+
+// GetNgrokJobsByStatus implements ChallengeTransactions.
+// Oldest jobs first.
+func (c ChallengeTransactionsImpl) GetNgrokJobsByStatus(status string) ([]models.NgrokJob, error) {
+	var jobs []models.NgrokJob
+	res := c.db.Where("status = ?", status).Order("created_at ASC").Find(&jobs)
+	return jobs, res.Error
+}
+
+// UpdateNgrokJobStatus implements ChallengeTransactions.
+func (c ChallengeTransactionsImpl) UpdateNgrokJobStatus(id uuid.UUID, status string, message string) error {
+	return c.db.Model(&models.NgrokJob{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "message": message}).Error
+}
+
+// CompleteNgrokJob implements ChallengeTransactions.
+// The score is written together with the finished job, or not at all.
+func (c ChallengeTransactionsImpl) CompleteNgrokJob(job *models.NgrokJob, score *models.Score) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(score).Error; err != nil {
+			return err
+		}
+		job.ScoreID = &score.ID
+		return tx.Model(job).Select("status", "message", "checks", "latency_p50_ms", "latency_p95_ms", "latency_points", "score_id").Updates(job).Error
+	})
+}
 
 func CreateChallengeTransactions(logger *slog.Logger, db *gorm.DB) ChallengeTransactions {
 	return ChallengeTransactionsImpl{logger: logger, db: db}
@@ -0,0 +1,70 @@
+package transactions
+
+import (
+	"generate_technical_challenge_2025/internal/database/models"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CohortTransactions interface {
+	UpsertCohort(*models.Cohort) error
+	GetCohortByName(string) (*models.Cohort, bool, error)
+	GetCohorts() ([]models.Cohort, error)
+	AssignMembersWithoutCohort(uuid.UUID) (int64, error)
+}
+
+type CohortTransactionsImpl struct {
+	logger *slog.Logger
+	db     *gorm.DB
+}
+
+func CreateCohortTransactions(logger *slog.Logger, db *gorm.DB) CohortTransactions {
+	return &CohortTransactionsImpl{logger: logger, db: db}
+}
+
+// UpsertCohort implements CohortTransactions.
+// Creates the cohort, or updates the window, challenges and version of the cohort with the same name. Callers keep
+// the version of an existing cohort unless it was pinned, see services.CohortConfig.
+func (c *CohortTransactionsImpl) UpsertCohort(cohort *models.Cohort) error {
+	var existing models.Cohort
+	res := c.db.Where("name = ?", cohort.Name).Limit(1).Find(&existing)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return c.db.Create(cohort).Error
+	}
+	cohort.ID = existing.ID
+	cohort.CreatedAt = existing.CreatedAt
+	return c.db.Model(&existing).
+		Select("opens_at", "closes_at", "challenge_types", "challenge_version", "updated_at").
+		Updates(cohort).Error
+}
+
+// GetCohortByName implements CohortTransactions.
+// Returns the cohort and whether it exists.
+func (c *CohortTransactionsImpl) GetCohortByName(name string) (*models.Cohort, bool, error) {
+	var cohort models.Cohort
+	res := c.db.Where("name = ?", name).Limit(1).Find(&cohort)
+	if res.Error != nil {
+		return nil, false, res.Error
+	}
+	return &cohort, res.RowsAffected > 0, nil
+}
+
+// GetCohorts implements CohortTransactions.
+// Cohorts that open first come first.
+func (c *CohortTransactionsImpl) GetCohorts() ([]models.Cohort, error) {
+	var cohorts []models.Cohort
+	res := c.db.Order("opens_at ASC, name ASC").Find(&cohorts)
+	return cohorts, res.Error
+}
+
+// AssignMembersWithoutCohort implements CohortTransactions.
+// Moves members that registered before cohorts existed into the given cohort, returning how many were moved.
+func (c *CohortTransactionsImpl) AssignMembersWithoutCohort(cohortID uuid.UUID) (int64, error) {
+	res := c.db.Model(&models.Member{}).Where("cohort_id IS NULL").Update("cohort_id", cohortID)
+	return res.RowsAffected, res.Error
+}
@@ -6,15 +6,33 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MemberTransactions interface {
 	InsertMember(*models.Member) (*uuid.UUID, error)
 	InsertScore(*models.Score) (int, error)
 	BatchInsertFrontendUsage([]models.FrontendUsage) error
-	GetMember(string, string) (*uuid.UUID, error)
-	MemberExistsByEmailAndNuid(string, string) (bool, error)
+	GetMember(string, string, uuid.UUID) (*models.Member, bool, error)
+	MemberExistsByEmailAndNuid(string, string, uuid.UUID) (bool, error)
 	MemberExistsById(uuid.UUID) (bool, error)
+	GetMemberWithCohort(uuid.UUID) (*models.Member, bool, error)
+	ReserveAttempt(uuid.UUID, string, int) (int, bool, error)
+	RefundAttempt(uuid.UUID, string) error
+	GetAttemptCounts(uuid.UUID) (map[string]int, error)
+	ResetAttempts(uuid.UUID, string) error
+	FindMembers(string, string) ([]models.Member, error)
+	GetScores(uuid.UUID) ([]models.Score, error)
+	GetFrontendUsageSummary(uuid.UUID) (models.FrontendUsageSummary, error)
+	GetCohortMembers(uuid.UUID) ([]models.Member, error)
+	GetScoresOfMembers([]uuid.UUID) ([]models.Score, error)
+	GetFrontendUsageSummaries([]uuid.UUID) (map[uuid.UUID]models.FrontendUsageSummary, error)
+	GetBestScores(string, uuid.UUID, int, int) ([]models.BestScore, error)
+	UpsertVerificationCode(*models.VerificationCode) error
+	GetVerificationCode(uuid.UUID) (*models.VerificationCode, bool, error)
+	DeleteVerificationCode(uuid.UUID) error
+	RecordVerificationAttempt(uuid.UUID, int) (bool, error)
+	VerifyMember(uuid.UUID) error
 }
 
 type MemberTransactionsImpl struct {
@@ -32,16 +50,29 @@ func (u *MemberTransactionsImpl) MemberExistsById(id uuid.UUID) (bool, error) {
 	return res.RowsAffected > 0, nil
 }
 
+// GetMemberWithCohort implements MemberTransactions.
+// Returns the member along with their cohort, and whether the member exists.
+func (u *MemberTransactionsImpl) GetMemberWithCohort(id uuid.UUID) (*models.Member, bool, error) {
+	var member models.Member
+	res := u.db.Preload("Cohort").Where("id = ?", id).Limit(1).Find(&member)
+	if res.Error != nil {
+		return nil, false, res.Error
+	}
+	return &member, res.RowsAffected > 0, nil
+}
+
 func CreateMemberTransactions(logger *slog.Logger, db *gorm.DB) MemberTransactions {
 	return &MemberTransactionsImpl{logger: logger, db: db}
 }
 
 // MemberExists implements MemberTransactions.
-func (u *MemberTransactionsImpl) MemberExistsByEmailAndNuid(email string, nuid string) (bool, error) {
+// Only members of the given cohort are checked.
+func (u *MemberTransactionsImpl) MemberExistsByEmailAndNuid(email string, nuid string, cohortID uuid.UUID) (bool, error) {
 	var member models.Member
 	res := u.db.Where(&models.Member{
-		Email: email,
-		Nuid:  nuid,
+		Email:    email,
+		Nuid:     nuid,
+		CohortID: cohortID,
 	}).Limit(1).Find(&member)
 	if res.Error != nil {
 		return false, res.Error
@@ -66,16 +97,19 @@ func (u MemberTransactionsImpl) InsertScore(score *models.Score) (int, error) {
 }
 
 // GetMember implements MemberTransactions.
-func (u *MemberTransactionsImpl) GetMember(email string, nuid string) (*uuid.UUID, error) {
+// Looks in the given cohort, or returns the latest registration in any cohort when given uuid.Nil. Returns the member
+// along with their cohort, and whether the member exists.
+func (u *MemberTransactionsImpl) GetMember(email string, nuid string, cohortID uuid.UUID) (*models.Member, bool, error) {
 	var member models.Member
-	res := u.db.Where(&models.Member{
-		Email: email,
-		Nuid:  nuid,
-	}).First(&member)
+	res := u.db.Preload("Cohort").Where(&models.Member{
+		Email:    email,
+		Nuid:     nuid,
+		CohortID: cohortID,
+	}).Order("created_at DESC").Limit(1).Find(&member)
 	if res.Error != nil {
-		return nil, res.Error
+		return nil, false, res.Error
 	}
-	return &member.ID, nil
+	return &member, res.RowsAffected > 0, nil
 }
 
 func (u *MemberTransactionsImpl) BatchInsertFrontendUsage(usages []models.FrontendUsage) error {
@@ -84,3 +118,204 @@ func (u *MemberTransactionsImpl) BatchInsertFrontendUsage(usages []models.Fronte
 	}
 	return u.db.Create(&usages).Error
 }
+
+// ReserveAttempt implements MemberTransactions.
+// Counts one more attempt at the challenge, unless the member has already used the limit (0 for no limit).
+// Returns the attempts used afterwards and whether the attempt was counted, in a single statement so that
+// concurrent submissions can never go over the limit.
+func (u *MemberTransactionsImpl) ReserveAttempt(id uuid.UUID, challengeType string, limit int) (int, bool, error) {
+	var used int
+	res := u.db.Raw(`INSERT INTO attempt_counts (user_id, challenge_type, used, updated_at) VALUES (?, ?, 1, NOW())
+		ON CONFLICT (user_id, challenge_type) DO UPDATE SET used = attempt_counts.used + 1, updated_at = NOW()
+		WHERE ? <= 0 OR attempt_counts.used < ?
+		RETURNING used`, id, challengeType, limit, limit).Scan(&used)
+	if res.Error != nil {
+		return 0, false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return limit, false, nil
+	}
+	return used, true, nil
+}
+
+// RefundAttempt implements MemberTransactions.
+// Gives back an attempt that was reserved for a submission that could not be graded.
+func (u *MemberTransactionsImpl) RefundAttempt(id uuid.UUID, challengeType string) error {
+	return u.db.Model(&models.AttemptCount{}).
+		Where("user_id = ? AND challenge_type = ? AND used > 0", id, challengeType).
+		Updates(map[string]any{"used": gorm.Expr("used - 1"), "updated_at": gorm.Expr("NOW()")}).Error
+}
+
+// GetAttemptCounts implements MemberTransactions.
+// Returns the attempts used keyed by challenge type, challenges that were never submitted are left out.
+func (u *MemberTransactionsImpl) GetAttemptCounts(id uuid.UUID) (map[string]int, error) {
+	var counts []models.AttemptCount
+	if err := u.db.Where("user_id = ?", id).Find(&counts).Error; err != nil {
+		return nil, err
+	}
+	used := make(map[string]int, len(counts))
+	for _, count := range counts {
+		used[count.ChallengeType] = count.Used
+	}
+	return used, nil
+}
+
+// ResetAttempts implements MemberTransactions.
+// Gives the member back every attempt at the challenge.
+func (u *MemberTransactionsImpl) ResetAttempts(id uuid.UUID, challengeType string) error {
+	return u.db.Where("user_id = ? AND challenge_type = ?", id, challengeType).Delete(&models.AttemptCount{}).Error
+}
+
+// FindMembers implements MemberTransactions.
+// Matches on the email, the NUID, or both when both are given, along with their cohorts. Newest registrations first.
+// Every member matches when neither is given.
+func (u *MemberTransactionsImpl) FindMembers(email string, nuid string) ([]models.Member, error) {
+	var members []models.Member
+	res := u.db.Preload("Cohort").Where(&models.Member{
+		Email: email,
+		Nuid:  nuid,
+	}).Order("created_at DESC").Find(&members)
+	return members, res.Error
+}
+
+// GetScores implements MemberTransactions.
+// Every score of the member, newest first.
+func (u *MemberTransactionsImpl) GetScores(id uuid.UUID) ([]models.Score, error) {
+	var scores []models.Score
+	res := u.db.Where("user_id = ?", id).Order("created_at DESC").Find(&scores)
+	return scores, res.Error
+}
+
+// GetFrontendUsageSummary implements MemberTransactions.
+func (u *MemberTransactionsImpl) GetFrontendUsageSummary(id uuid.UUID) (models.FrontendUsageSummary, error) {
+	var summary models.FrontendUsageSummary
+	res := u.db.Model(&models.FrontendUsage{}).
+		Select("COUNT(*) AS requests, MIN(timestamp) AS first_request_at, MAX(timestamp) AS last_request_at").
+		Where("user_id = ?", id).
+		Scan(&summary)
+	summary.UserID = id
+	return summary, res.Error
+}
+
+// GetCohortMembers implements MemberTransactions.
+// Every member of the cohort, or of every cohort when given uuid.Nil, along with their cohorts. Oldest registrations first.
+func (u *MemberTransactionsImpl) GetCohortMembers(cohortID uuid.UUID) ([]models.Member, error) {
+	var members []models.Member
+	res := u.db.Preload("Cohort").Where(&models.Member{CohortID: cohortID}).Order("created_at, email").Find(&members)
+	return members, res.Error
+}
+
+// GetScoresOfMembers implements MemberTransactions.
+// Every score of the members, newest first.
+func (u *MemberTransactionsImpl) GetScoresOfMembers(ids []uuid.UUID) ([]models.Score, error) {
+	var scores []models.Score
+	if len(ids) == 0 {
+		return scores, nil
+	}
+	res := u.db.Where("user_id IN ?", ids).Order("created_at DESC").Find(&scores)
+	return scores, res.Error
+}
+
+// GetFrontendUsageSummaries implements MemberTransactions.
+// Members that never made a request are left out.
+func (u *MemberTransactionsImpl) GetFrontendUsageSummaries(ids []uuid.UUID) (map[uuid.UUID]models.FrontendUsageSummary, error) {
+	summaries := map[uuid.UUID]models.FrontendUsageSummary{}
+	if len(ids) == 0 {
+		return summaries, nil
+	}
+	var rows []models.FrontendUsageSummary
+	res := u.db.Model(&models.FrontendUsage{}).
+		Select("user_id, COUNT(*) AS requests, MIN(timestamp) AS first_request_at, MAX(timestamp) AS last_request_at").
+		Where("user_id IN ?", ids).
+		Group("user_id").
+		Scan(&rows)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	for _, row := range rows {
+		summaries[row.UserID] = row
+	}
+	return summaries, nil
+}
+
+// GetBestScores implements MemberTransactions.
+// The best valid score of every member at the challenge version, best first with ties going to whoever reached it
+// first. Only members of the cohort are ranked unless given uuid.Nil, and every member is returned when limit <= 0.
+func (u *MemberTransactionsImpl) GetBestScores(challengeType string, cohortID uuid.UUID, challengeVersion int, limit int) ([]models.BestScore, error) {
+	attempts := u.db.Model(&models.Score{}).
+		Select("user_id, COUNT(*) AS attempts").
+		Where("challenge_type = ? AND challenge_version = ?", challengeType, challengeVersion).
+		Group("user_id")
+	ranked := u.db.Model(&models.Score{}).
+		Select("user_id, score, created_at, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY score, created_at) AS position").
+		Where("challenge_type = ? AND challenge_version = ? AND is_valid", challengeType, challengeVersion)
+	query := u.db.Table("(?) AS r", ranked).
+		Select("m.id AS user_id, m.email, m.nuid, r.score, a.attempts, r.created_at AS best_at").
+		Joins("JOIN (?) AS a ON a.user_id = r.user_id", attempts).
+		Joins("JOIN members m ON m.id = r.user_id").
+		Where("r.position = 1").
+		Order("r.score, r.created_at, m.email")
+	if cohortID != uuid.Nil {
+		query = query.Where("m.cohort_id = ?", cohortID)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var best []models.BestScore
+	res := query.Scan(&best)
+	return best, res.Error
+}
+
+// UpsertVerificationCode implements MemberTransactions.
+// Replaces the member's earlier code, along with its wrong attempts.
+func (u *MemberTransactionsImpl) UpsertVerificationCode(code *models.VerificationCode) error {
+	return u.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"code_hash":  code.CodeHash,
+			"attempts":   0,
+			"expires_at": code.ExpiresAt,
+			"created_at": code.CreatedAt,
+		}),
+	}).Create(code).Error
+}
+
+// GetVerificationCode implements MemberTransactions.
+// Returns the member's latest code, and whether one was ever sent since they last verified.
+func (u *MemberTransactionsImpl) GetVerificationCode(id uuid.UUID) (*models.VerificationCode, bool, error) {
+	var code models.VerificationCode
+	res := u.db.Where("user_id = ?", id).Limit(1).Find(&code)
+	if res.Error != nil {
+		return nil, false, res.Error
+	}
+	return &code, res.RowsAffected > 0, nil
+}
+
+// DeleteVerificationCode implements MemberTransactions.
+func (u *MemberTransactionsImpl) DeleteVerificationCode(id uuid.UUID) error {
+	return u.db.Where("user_id = ?", id).Delete(&models.VerificationCode{}).Error
+}
+
+// RecordVerificationAttempt implements MemberTransactions.
+// Counts one more code entered against the member's latest code, unless maxAttempts were already entered.
+// Returns whether it was counted, in a single statement so that concurrent guesses can never go over the limit.
+func (u *MemberTransactionsImpl) RecordVerificationAttempt(id uuid.UUID, maxAttempts int) (bool, error) {
+	res := u.db.Model(&models.VerificationCode{}).
+		Where("user_id = ? AND attempts < ?", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// VerifyMember implements MemberTransactions.
+// Marks the member as verified and uses up their code, together or not at all.
+func (u *MemberTransactionsImpl) VerifyMember(id uuid.UUID) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Member{}).Where("id = ?", id).Update("pending_verification", false).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", id).Delete(&models.VerificationCode{}).Error
+	})
+}
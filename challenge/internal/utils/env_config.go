@@ -2,6 +2,8 @@ package utils
 
 import (
 	"context"
+	"generate_technical_challenge_2025/internal/database/models"
+	"time"
 
 	"github.com/sethvargo/go-envconfig"
 )
@@ -18,6 +20,63 @@ type EnvConfig struct {
 	// Optional, default environment variables.
 	PORT      int    `env:"PORT, default=8081"`
 	LOG_LEVEL string `env:"LOG_LEVEL, default=INFO"`
+	// Number of ngrok submissions graded at the same time.
+	NGROK_WORKERS int `env:"NGROK_WORKERS, default=4"`
+	// How the latency of a member's ngrok server affects their score, see services.NgrokLatencyConfig. Left nil
+	// when not set, so that services.CreateNgrokLatencyConfig can fill in the defaults the grade CLI also uses.
+	NGROK_LATENCY_SAMPLES *int           `env:"NGROK_LATENCY_SAMPLES, noinit"`
+	NGROK_FAST_P95        *time.Duration `env:"NGROK_FAST_P95, noinit"`
+	NGROK_SLOW_P95        *time.Duration `env:"NGROK_SLOW_P95, noinit"`
+	NGROK_LATENCY_BONUS   *int           `env:"NGROK_LATENCY_BONUS, noinit"`
+	NGROK_LATENCY_PENALTY *int           `env:"NGROK_LATENCY_PENALTY, noinit"`
+	// Whether ngrok challenges that have a stress round are graded with it, see services.NgrokStressRequest.
+	NGROK_STRESS_ROUND bool `env:"NGROK_STRESS_ROUND, default=true"`
+
+	// Cohorts members register into, see services.CohortConfig. Members that registered before cohorts existed
+	// are moved into the default cohort, which is the only cohort when COHORTS_FILE is not set.
+	COHORTS_FILE   string `env:"COHORTS_FILE"`
+	DEFAULT_COHORT string `env:"DEFAULT_COHORT, default=Generate 2025"`
+
+	// How many times each member can submit each challenge, 0 for no limit.
+	ALGORITHM_MAX_ATTEMPTS int `env:"ALGORITHM_MAX_ATTEMPTS, default=20"`
+	NGROK_MAX_ATTEMPTS     int `env:"NGROK_MAX_ATTEMPTS, default=20"`
+
+	// How long leaderboards are served from memory before they are read again, and how many members they rank.
+	LEADERBOARD_CACHE_TTL time.Duration `env:"LEADERBOARD_CACHE_TTL, default=30s"`
+	LEADERBOARD_SIZE      int           `env:"LEADERBOARD_SIZE, default=100"`
+
+	// How the codes members verify their email with are sent: log (to the server log), file (appended to MAIL_FILE)
+	// or smtp, see services.CreateMailer.
+	MAILER        string `env:"MAILER, default=log"`
+	MAIL_FILE     string `env:"MAIL_FILE, default=mail.log"`
+	MAIL_FROM     string `env:"MAIL_FROM"`
+	SMTP_HOST     string `env:"SMTP_HOST"`
+	SMTP_PORT     int    `env:"SMTP_PORT, default=587"`
+	SMTP_USERNAME string `env:"SMTP_USERNAME"`
+	SMTP_PASSWORD string `env:"SMTP_PASSWORD"`
+
+	// How long verification codes last, how long members wait before asking for another, and how many codes
+	// they can enter before they need a new one. See services.VerificationConfig.
+	VERIFICATION_CODE_TTL     time.Duration `env:"VERIFICATION_CODE_TTL, default=15m"`
+	VERIFICATION_RESEND_DELAY time.Duration `env:"VERIFICATION_RESEND_DELAY, default=1m"`
+	VERIFICATION_MAX_ATTEMPTS int           `env:"VERIFICATION_MAX_ATTEMPTS, default=5"`
+
+	// Comma separated bearer tokens for the admin API. Interviewers can look candidates up, admins can also
+	// reset their attempts. The admin API rejects every request when neither is set.
+	INTERVIEWER_TOKENS []string `env:"INTERVIEWER_TOKENS"`
+	ADMIN_TOKENS       []string `env:"ADMIN_TOKENS"`
+}
+
+// Attempt limits keyed by challenge type, challenges without a limit are left out.
+func (cfg EnvConfig) ChallengeAttemptLimits() map[string]int {
+	limits := map[string]int{}
+	if cfg.ALGORITHM_MAX_ATTEMPTS > 0 {
+		limits[models.ALGORITHM_CHALLENGE_TYPE] = cfg.ALGORITHM_MAX_ATTEMPTS
+	}
+	if cfg.NGROK_MAX_ATTEMPTS > 0 {
+		limits[models.NGROK_CHALLENGE_TYPE] = cfg.NGROK_MAX_ATTEMPTS
+	}
+	return limits
 }
 
 // Loads the environment variables as an EnvConfig
@@ -42,3 +42,15 @@ func (rl *RateLimiter) Allow(uuid string) bool {
 	limiter := rl.GetLimiter(uuid)
 	return limiter.Allow()
 }
+
+// Tokens returns how many requests this UUID can make right now, without using any of them.
+func (rl *RateLimiter) Tokens(uuid string) int {
+	rl.mu.RLock()
+	limiter, exists := rl.limiters[uuid]
+	rl.mu.RUnlock()
+
+	if !exists {
+		return rl.burst
+	}
+	return int(limiter.Tokens())
+}
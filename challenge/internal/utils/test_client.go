@@ -53,6 +53,24 @@ func (t TestClient) GetLatestScore(userID string, challengeType string) (int, bo
 	return score.Score, score.IsValid, true
 }
 
+// Submission with its waves, isFound.
+func (t TestClient) GetLatestSubmission(userID string) (models.Submission, bool) {
+	var submission models.Submission
+	result := t.db.Preload("Waves").Where("user_id = ?", userID).
+		Order("created_at DESC").First(&submission)
+	if result.Error != nil {
+		return submission, false
+	}
+	return submission, true
+}
+
+// Moves the member into the named cohort, e.g. one that has already closed.
+func (t TestClient) SetMemberCohort(userID string, cohortName string, tt *testing.T) {
+	var cohort models.Cohort
+	require.NoError(tt, t.db.Where("name = ?", cohortName).First(&cohort).Error)
+	require.NoError(tt, t.db.Model(&models.Member{}).Where("id = ?", userID).Update("cohort_id", cohort.ID).Error)
+}
+
 // Blocks until the server is ready.
 func (t TestClient) CheckServer(timeout time.Duration) bool {
 	start := time.Now()
@@ -97,6 +115,13 @@ func (t TestClient) POST(endpoint string) TestVerify {
 	return t.internalWrapper(fn)
 }
 
+func (t TestClient) DELETE(endpoint string) TestVerify {
+	fn := func(body io.Reader) (*http.Request, error) {
+		return http.NewRequest("DELETE", t.baseurl+endpoint, body)
+	}
+	return t.internalWrapper(fn)
+}
+
 func (t TestClient) internalWrapper(reqSupplier func(body io.Reader) (*http.Request, error)) TestVerify {
 	var req *http.Request
 	if t.body != nil {
@@ -5,6 +5,8 @@ GRANT USAGE ON SCHEMA public TO readonly_interviewers;
 
 GRANT SELECT ON members TO readonly_interviewers;
 GRANT SELECT ON scores TO readonly_interviewers;
+GRANT SELECT ON cohorts TO readonly_interviewers;
+GRANT SELECT ON frontend_usages TO readonly_interviewers;
 
 CREATE USER interviewer WITH PASSWORD '';
 